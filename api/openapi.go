@@ -0,0 +1,8 @@
+package api
+
+import _ "embed"
+
+// OpenAPISpec 은 balance-api 의 OpenAPI 3 문서이다. 라우트나 response 구조체를 바꾸면 함께 수정해야 하며, openapi_test.go 가 불일치를 검사한다.
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"onbloc/internal/handler"
+	"onbloc/internal/health"
+	"onbloc/internal/request"
+	"onbloc/internal/response"
+	"onbloc/internal/router"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+type openAPISchema struct {
+	Type       string                   `json:"type"`
+	Format     string                   `json:"format"`
+	Ref        string                   `json:"$ref"`
+	Items      *openAPISchema           `json:"items"`
+	Properties map[string]openAPISchema `json:"properties"`
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// schemaTypes 는 components.schemas 와 Go 구조체의 대응표이다. response 패키지에 타입을 추가하면 여기와 openapi.json 에 함께 추가해야 한다.
+var schemaTypes = map[string]interface{}{
+	"TokenBalance":                 response.TokenBalance{},
+	"BalancesResponse":             response.BalancesResponse{},
+	"AccountBalance":               response.AccountBalance{},
+	"AccountBalancesResponse":      response.AccountBalancesResponse{},
+	"HoldersResponse":              response.HoldersResponse{},
+	"Transfer":                     response.Transfer{},
+	"TransfersResponse":            response.TransfersResponse{},
+	"AddressBalances":              response.AddressBalances{},
+	"BalanceQueryResponse":         response.BalanceQueryResponse{},
+	"BalanceQueryRequest":          request.BalanceQueryRequest{},
+	"Block":                        response.Block{},
+	"BlocksResponse":               response.BlocksResponse{},
+	"GasFee":                       response.GasFee{},
+	"Transaction":                  response.Transaction{},
+	"TransactionsResponse":         response.TransactionsResponse{},
+	"Message":                      response.Message{},
+	"BankMsgSend":                  response.BankMsgSend{},
+	"MsgCall":                      response.MsgCall{},
+	"MsgAddPackage":                response.MsgAddPackage{},
+	"MsgRun":                       response.MsgRun{},
+	"Package":                      response.Package{},
+	"TokenEvent":                   response.TokenEvent{},
+	"Activity":                     response.Activity{},
+	"ActivitiesResponse":           response.ActivitiesResponse{},
+	"Token":                        response.Token{},
+	"SearchResponse":               response.SearchResponse{},
+	"VolumeBucket":                 response.VolumeBucket{},
+	"VolumeResponse":               response.VolumeResponse{},
+	"WebhookSubscriptionRequest":   request.WebhookSubscriptionRequest{},
+	"WebhookSubscription":          response.WebhookSubscription{},
+	"WebhookSubscriptionsResponse": response.WebhookSubscriptionsResponse{},
+	"WebhookDelivery":              response.WebhookDelivery{},
+	"WebhookDeliveriesResponse":    response.WebhookDeliveriesResponse{},
+	"StreamMessage":                response.StreamMessage{},
+	"StreamTransfer":               response.StreamTransfer{},
+	"GraphQLRequest":               handler.GraphQLRequest{},
+	"ErrorResponse":                response.ErrorResponse{},
+	"ErrorBody":                    response.ErrorBody{},
+	"FieldError":                   response.FieldError{},
+}
+
+// graphQLOnlyTypes 는 GraphQL 에서만 사용하는 제네릭 타입이라 REST 스펙에 포함하지 않는다.
+var graphQLOnlyTypes = map[string]bool{"Edge": true, "PageInfo": true, "Connection": true}
+
+// unspecifiedRoutes 는 문서 자체나 운영용 라우트라 스펙에 포함하지 않는다.
+var unspecifiedRoutes = map[string]bool{"/openapi.json": true, "/docs": true, "/metrics": true, "/healthz": true, "/readyz": true}
+
+func loadDocument(t *testing.T) openAPIDocument {
+	var document openAPIDocument
+	require.NoError(t, json.Unmarshal(OpenAPISpec, &document))
+	return document
+}
+
+func TestOpenAPISpec_Routes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	document := loadDocument(t)
+	assert.True(t, strings.HasPrefix(document.OpenAPI, "3."))
+
+	handlers := router.Handlers{
+		Balance: handler.NewBalanceAPIHandler(nil, ""),
+		Stream:  handler.NewStreamHandler(nil, ""),
+		GraphQL: handler.NewGraphQLHandler(graphql.Schema{}, ""),
+		Docs:    handler.NewDocsHandler(OpenAPISpec),
+		Health:  health.NewChecker(),
+	}
+	engine := router.New(handlers)
+
+	specOperations := make(map[string]bool)
+	for path, operations := range document.Paths {
+		for method := range operations {
+			specOperations[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	param := regexp.MustCompile(`[:*](\w+)`)
+	covered := make(map[string]bool)
+	for _, route := range engine.Routes() {
+		path := strings.TrimPrefix(route.Path, "/chains/:chainId")
+		if unspecifiedRoutes[path] {
+			continue
+		}
+
+		// /tokens/*path 는 토큰 경로에 슬래시가 포함되어 있어 스펙의 /tokens/... 경로 전체를 처리한다. (router/tokens.go)
+		// 각 경로가 어느 핸들러로 가는지는 아래에서 따로 확인한다.
+		if path == "/tokens/*path" {
+			continue
+		}
+
+		operation := route.Method + " " + param.ReplaceAllString(path, "{$1}")
+		assert.True(t, specOperations[operation], "route %s %s is not documented", route.Method, route.Path)
+		covered[operation] = true
+	}
+
+	t.Run("스펙의 /tokens/... 경로는 operationId 와 같은 이름의 핸들러로 간다", func(t *testing.T) {
+		for path, operations := range document.Paths {
+			if !strings.HasPrefix(path, "/tokens/") {
+				continue
+			}
+			for method, raw := range operations {
+				var operation struct {
+					OperationID string `json:"operationId"`
+				}
+				require.NoError(t, json.Unmarshal(raw, &operation))
+				require.Equal(t, "get", method, path)
+
+				// 토큰 경로가 action 이름으로 끝나는 경우도 함께 확인한다.
+				for _, tokenPath := range []string{"gno.land/r/demo/foo", "gno.land/r/demo/volume"} {
+					exists := func(candidate string) bool { return candidate == tokenPath }
+					resolved, resolvedTokenPath, ok := router.ResolveTokenRoute(handlers, strings.TrimPrefix(strings.ReplaceAll(path, "{tokenPath}", tokenPath), "/tokens"), exists)
+					if !assert.True(t, ok, "documented operation %s has no token route", path) {
+						continue
+					}
+					assert.Equal(t, strings.ToUpper(operation.OperationID[:1])+operation.OperationID[1:], handlerName(resolved), path)
+					if strings.Contains(path, "{tokenPath}") {
+						assert.Equal(t, tokenPath, resolvedTokenPath, path)
+					}
+				}
+				covered["GET "+path] = true
+			}
+		}
+	})
+
+	for operation := range specOperations {
+		assert.True(t, covered[operation], "documented operation %s has no gin route", operation)
+	}
+
+	t.Run("/openapi.json 은 스펙을 그대로 반환한다", func(t *testing.T) {
+		w := performRequest(engine, "/openapi.json")
+		assert.Equal(t, http.StatusOK, w.code)
+		assert.JSONEq(t, string(OpenAPISpec), w.body)
+	})
+
+	t.Run("/docs 는 Swagger UI 를 반환한다", func(t *testing.T) {
+		w := performRequest(engine, "/docs")
+		assert.Equal(t, http.StatusOK, w.code)
+		assert.Contains(t, w.body, "SwaggerUIBundle")
+	})
+}
+
+func TestOpenAPISpec_Schemas(t *testing.T) {
+	document := loadDocument(t)
+
+	for name := range document.Components.Schemas {
+		assert.Contains(t, schemaTypes, name, "schema %s has no Go type in schemaTypes", name)
+	}
+
+	for name, value := range schemaTypes {
+		t.Run(name, func(t *testing.T) {
+			schema, exists := document.Components.Schemas[name]
+			require.True(t, exists, "schema %s is not documented", name)
+
+			fields := jsonFields(reflect.TypeOf(value))
+			assert.ElementsMatch(t, sortedKeys(schema.Properties), sortedKeys(fields), "properties of %s differ from Go struct", name)
+			for field, fieldType := range fields {
+				if property, exists := schema.Properties[field]; exists {
+					assertType(t, name+"."+field, property, fieldType)
+				}
+			}
+		})
+	}
+
+	t.Run("response 패키지의 모든 타입이 스펙에 포함된다", func(t *testing.T) {
+		for _, typeName := range declaredTypes(t, "../internal/response") {
+			if graphQLOnlyTypes[typeName] {
+				continue
+			}
+			_, exists := document.Components.Schemas[typeName]
+			assert.True(t, exists, "response.%s is not documented", typeName)
+		}
+	})
+}
+
+// handlerName 은 메서드 값 핸들러의 메서드 이름이다. (ex. handler.BalanceAPIHandler.GetToken-fm → GetToken)
+func handlerName(h gin.HandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(h).Pointer()).Name()
+	return strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "-fm")
+}
+
+type recordedResponse struct {
+	code int
+	body string
+}
+
+func performRequest(engine *gin.Engine, path string) recordedResponse {
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return recordedResponse{code: w.Code, body: w.Body.String()}
+}
+
+func jsonFields(structType reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func assertType(t *testing.T, name string, property openAPISchema, fieldType reflect.Type) {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch {
+	case fieldType == timeType:
+		assert.Equal(t, "date-time", property.Format, name)
+	case fieldType.Kind() == reflect.String:
+		assert.Equal(t, "string", property.Type, name)
+	case fieldType.Kind() == reflect.Bool:
+		assert.Equal(t, "boolean", property.Type, name)
+	case fieldType.Kind() >= reflect.Int && fieldType.Kind() <= reflect.Uint64:
+		assert.Equal(t, "integer", property.Type, name)
+	case fieldType.Kind() == reflect.Slice:
+		require.Equal(t, "array", property.Type, name)
+		require.NotNil(t, property.Items, name)
+		assertType(t, name+"[]", *property.Items, fieldType.Elem())
+	case fieldType.Kind() == reflect.Struct:
+		assert.Equal(t, "#/components/schemas/"+fieldType.Name(), property.Ref, name)
+	case fieldType.Kind() == reflect.Map:
+		assert.Equal(t, "object", property.Type, name)
+	}
+}
+
+func declaredTypes(t *testing.T, dir string) []string {
+	packages, err := parser.ParseDir(token.NewFileSet(), dir, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	var names []string
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					names = append(names, spec.(*ast.TypeSpec).Name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
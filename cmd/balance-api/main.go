@@ -4,15 +4,30 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"onbloc/api"
+	"onbloc/internal/config"
 	balance_api_config "onbloc/internal/config/balance-api"
+	graphql_api "onbloc/internal/graphql-api"
+	grpc_api "onbloc/internal/grpc-api"
 	handler2 "onbloc/internal/handler"
+	"onbloc/internal/health"
+	"onbloc/internal/logging"
+	"onbloc/internal/metrics"
+	"onbloc/internal/migration"
 	"onbloc/internal/repository/postgresdb"
+	"onbloc/internal/router"
 	balance_api_service "onbloc/internal/service/balance-api-service"
+	"onbloc/internal/stream"
+	"onbloc/internal/tracing"
+	"onbloc/pkg/caching"
+	balancev1 "onbloc/pkg/pb/balance/v1"
 	"os"
 	"os/signal"
 	"syscall"
@@ -26,30 +41,61 @@ func main() {
 
 	conf, err := balance_api_config.Load(path)
 	if err != nil {
-		log.Println(err)
 		panic(err)
 	}
 
+	if _, err = logging.Init(conf.Logging, "balance-api"); err != nil {
+		panic(err)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), conf.Tracing, "balance-api")
+	if err != nil {
+		panic(err)
+	}
+	defer shutdownTracing(context.Background())
+
 	db, err := gorm.Open(postgres.Open(conf.DB.GetDsn()))
 	if err != nil {
 		panic(err)
 	}
+	if err = migration.Check(context.Background(), db); err != nil {
+		panic(err)
+	}
 
 	repository := postgresdb.NewRepository(db)
+	checker := health.NewChecker()
+	if sqlDB, err := db.DB(); err == nil {
+		metrics.RegisterDBStats(sqlDB)
+		checker.AddReadiness("postgres", health.Postgres(sqlDB))
+	}
 
-	service := balance_api_service.NewService(repository)
-	handler := handler2.NewBalanceAPIHandler(service)
-	r := gin.Default()
-	tokenGroup := r.Group("/tokens")
-	tokenGroup.GET("/*wildcard", func(c *gin.Context) {
-		wildcard := c.Param("wildcard")
-		if wildcard == "/balances" {
-			handler.GetTokenBalances(c)
-		} else if wildcard == "/transfer-history" {
-			handler.GetTokenTransferHistory(c)
-		} else {
-			handler.GetTokenPathBalances(c)
-		}
+	cache := newCache(conf.Cache)
+	if redis, ok := cache.(*caching.RedisClient); ok {
+		checker.AddReadiness("redis", redis.Ping)
+	}
+	service := balance_api_service.NewService(repository, cache, conf.Cache.GetTTL())
+	handler := handler2.NewBalanceAPIHandler(service, conf.ChainID)
+
+	hub := stream.NewHub()
+	listenCtx, stopListen := context.WithCancel(context.Background())
+	defer stopListen()
+	go stream.Listen(listenCtx, conf.DB.GetDsn(), hub)
+	streamHandler := handler2.NewStreamHandler(hub, conf.ChainID)
+
+	schema, err := graphql_api.NewSchema(service)
+	if err != nil {
+		panic(err)
+	}
+	graphQLHandler := handler2.NewGraphQLHandler(schema, conf.ChainID)
+
+	r := router.New(router.Handlers{
+		Balance: handler,
+		Stream:  streamHandler,
+		GraphQL: graphQLHandler,
+		Docs:    handler2.NewDocsHandler(api.OpenAPISpec),
+		Health:  checker,
+
+		WebhookAPIKeys: conf.WebhookAPIKeys,
 	})
 
 	srv := &http.Server{
@@ -58,23 +104,54 @@ func main() {
 	}
 	go func() {
 		if err = srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatal("listen: %s\n", err)
+			slog.Error("http server failed", logging.Err(err))
+			os.Exit(1)
 		}
 	}()
 
+	grpcServer := grpc.NewServer()
+	balancev1.RegisterBalanceServiceServer(grpcServer, grpc_api.NewServer(service, hub, conf.ChainID))
+	reflection.Register(grpcServer)
+	if conf.GrpcPort > 0 {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", conf.GrpcPort))
+		if err != nil {
+			slog.Error("grpc listen failed", logging.Err(err))
+			os.Exit(1)
+		}
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				slog.Error("grpc server failed", logging.Err(err))
+				os.Exit(1)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	log.Println("Shutdown Server ...")
+	slog.Info("shutting down server")
+	grpcServer.GracefulStop()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err = srv.Shutdown(ctx); err != nil {
-		log.Fatal("Server Shutdown:", err)
+		slog.Error("server shutdown failed", logging.Err(err))
+		os.Exit(1)
 	}
 	select {
 	case <-ctx.Done():
-		log.Println("timeout of 5 seconds.")
+		slog.Info("shutdown timeout of 5 seconds")
+	}
+	slog.Info("server exiting")
+}
+
+func newCache(conf config.Cache) caching.Caching {
+	switch conf.Driver {
+	case "redis":
+		return caching.NewRedisClient(conf.Redis.GetAddr(), conf.Redis.Password, conf.Redis.DB)
+	case "lru":
+		return caching.NewLRU(conf.GetSize())
+	default:
+		return nil
 	}
-	log.Println("Server exiting")
 }
@@ -6,14 +6,22 @@ import (
 	"fmt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"log"
+	"log/slog"
+	"onbloc/internal/admin"
 	block_synchronizer_config "onbloc/internal/config/block-synchronizer"
+	"onbloc/internal/health"
+	"onbloc/internal/leader"
+	"onbloc/internal/logging"
+	"onbloc/internal/metrics"
+	"onbloc/internal/migration"
 	"onbloc/internal/repository/postgresdb"
 	block_synchronizer "onbloc/internal/service/block-synchronizer"
+	"onbloc/internal/shutdown"
+	"onbloc/internal/tracing"
 	"onbloc/internal/tx-indexer"
 	"onbloc/pkg/messaging"
-	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -28,11 +36,23 @@ func main() {
 		panic(err)
 	}
 
-	client := tx_indexer.NewClient(conf.TxIndexerEndPoint, time.Second*60)
+	if _, err = logging.Init(conf.Logging, "block-synchronizer"); err != nil {
+		panic(err)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), conf.Tracing, "block-synchronizer")
+	if err != nil {
+		panic(err)
+	}
+	defer shutdownTracing(context.Background())
+
 	db, err := gorm.Open(postgres.Open(conf.DB.GetDsn()), &gorm.Config{})
 	if err != nil {
 		panic(fmt.Sprintf("Failed to connect to database: %s\n", err.Error()))
 	}
+	if err = migration.Check(context.Background(), db); err != nil {
+		panic(err)
+	}
 
 	repository := postgresdb.NewRepository(db)
 
@@ -40,20 +60,67 @@ func main() {
 	if err != nil {
 		panic(fmt.Sprintf("Failed to connect to message queue: %s\n", err.Error()))
 	}
-	service := block_synchronizer.NewService(client, repository, messageQueue, conf.BackFillBatchSize, time.Duration(conf.SyncInterval))
+	checker := health.NewChecker()
+	if sqlDB, err := db.DB(); err == nil {
+		checker.AddReadiness("postgres", health.Postgres(sqlDB))
+	}
+	checker.AddReadiness("queue", messageQueue.Ping)
 
-	err = service.RunBackFill(context.Background())
-	if err != nil {
-		panic(err)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var wg sync.WaitGroup
+	for _, chain := range conf.GetChains() {
+		client := tx_indexer.NewClient(chain.TxIndexerEndPoint, time.Second*60)
+		service := block_synchronizer.NewService(chain.ChainID, client, repository, messageQueue, conf.BackFillBatchSize, time.Duration(conf.SyncInterval))
+		checker.AddReadiness("tx-indexer:"+chain.ChainID, client.Ping)
+		checker.AddLiveness("sync:"+chain.ChainID, service.Progress().Check(conf.Health.MaxLagBlocks, conf.Health.GetStallTimeout()))
+
+		// 리더인 인스턴스만 동기화하고, 나머지는 대기하다가 리더가 물러나거나 죽으면 이어받는다.
+		elector := leader.NewElector(repository, "block-synchronizer:"+chain.ChainID, conf.LeaderElection.GetLeaseTTL())
+		service.Progress().SetStandby(true)
+		metrics.SetLeader(chain.ChainID, false)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			elector.Run(ctx, func(ctx context.Context, token int64) {
+				service.Progress().SetStandby(false)
+				metrics.SetLeader(service.ChainID(), true)
+				defer func() {
+					service.Progress().SetStandby(true)
+					metrics.SetLeader(service.ChainID(), false)
+				}()
+				runSync(ctx, service.WithFencingToken(token, func(ctx context.Context) error {
+					return elector.Verify(ctx, token)
+				}))
+			})
+		}()
 	}
-	log.Println("back-fill done")
 
-	log.Println("synchronizer start!")
-	go service.RunRealtimeSync(context.Background())
+	adminServer := admin.Serve(conf.AdminPort, checker)
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	<-sigChan
+	<-ctx.Done()
+	stop()
+	slog.Info("shutting down, waiting for in-flight ranges", "timeout", conf.Shutdown.GetTimeout().String())
+	if !shutdown.Drain(&wg, conf.Shutdown.GetTimeout()) {
+		slog.Warn("shutdown timed out before in-flight ranges finished")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	adminServer.Shutdown(shutdownCtx)
+}
+
+// runSync 는 리더 임기 동안 백필 후 실시간 동기화를 실행한다. ctx 가 취소되면(종료, 리더 자격 상실) 진행 중인 구간을 마치고 돌아온다.
+func runSync(ctx context.Context, service *block_synchronizer.Service) {
+	if err := service.RunBackFill(ctx); err != nil {
+		if ctx.Err() == nil {
+			slog.Error("back-fill failed", logging.KeyChainID, service.ChainID(), logging.Err(err))
+		}
+		return
+	}
+	slog.Info("back-fill done", logging.KeyChainID, service.ChainID())
 
-	log.Println("Shutting down...")
+	slog.Info("realtime sync started", logging.KeyChainID, service.ChainID())
+	service.RunRealtimeSync(ctx)
 }
@@ -6,12 +6,22 @@ import (
 	"fmt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"log"
+	"log/slog"
+	"onbloc/internal/admin"
 	event_processor_config "onbloc/internal/config/event-processor"
 	"onbloc/internal/consumer"
+	"onbloc/internal/health"
+	"onbloc/internal/logging"
+	"onbloc/internal/migration"
 	"onbloc/internal/repository/postgresdb"
+	"onbloc/internal/shutdown"
+	"onbloc/internal/tracing"
 	"onbloc/pkg/caching"
 	"onbloc/pkg/messaging"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
 func main() {
@@ -21,10 +31,19 @@ func main() {
 
 	conf, err := event_processor_config.Load(path)
 	if err != nil {
-		log.Println(err)
 		panic(err)
 	}
 
+	if _, err = logging.Init(conf.Logging, "event-processor"); err != nil {
+		panic(err)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), conf.Tracing, "event-processor")
+	if err != nil {
+		panic(err)
+	}
+	defer shutdownTracing(context.Background())
+
 	messageQueue, err := messaging.NewSQSClient(context.TODO(), conf.MessageQueueUrl)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to connect to message queue: %s\n", err.Error()))
@@ -32,14 +51,47 @@ func main() {
 
 	redis := caching.NewRedisClient(conf.Caching.GetAddr(), conf.Caching.Password, conf.Caching.DB)
 
-	dsn := "host=localhost user=postgres password=password dbname=onbloc port=5432 sslmode=disable"
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(conf.DB.GetDsn()), &gorm.Config{})
+	if err != nil {
+		panic(fmt.Sprintf("Failed to connect to database: %s\n", err.Error()))
+	}
+	if err = migration.Check(context.Background(), db); err != nil {
+		panic(err)
+	}
 	repository := postgresdb.NewRepository(db)
 
 	eventProcessor := consumer.NewEventProcessor(redis, messageQueue, repository, conf.BatchSize)
-	log.Println("event-processor start!")
-	err = eventProcessor.Start(context.Background())
-	if err != nil {
-		log.Fatalf("Event processor failed: %v", err)
+
+	checker := health.NewChecker()
+	if sqlDB, err := db.DB(); err == nil {
+		checker.AddReadiness("postgres", health.Postgres(sqlDB))
 	}
+	checker.AddReadiness("queue", messageQueue.Ping)
+	checker.AddReadiness("redis", redis.Ping)
+	checker.AddLiveness("processing", eventProcessor.Heartbeat().Check(conf.Health.GetStallTimeout()))
+	adminServer := admin.Serve(conf.AdminPort, checker)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer stop()
+		slog.Info("event-processor started")
+		if err := eventProcessor.Start(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("event processor failed", logging.Err(err))
+		}
+	}()
+
+	<-ctx.Done()
+	slog.Info("shutting down, waiting for in-flight message", "timeout", conf.Shutdown.GetTimeout().String())
+	if !shutdown.Drain(&wg, conf.Shutdown.GetTimeout()) {
+		slog.Warn("shutdown timed out before in-flight message finished")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	adminServer.Shutdown(shutdownCtx)
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"log/slog"
+	"onbloc/internal/cli"
+	admin_cli_config "onbloc/internal/config/admin-cli"
+	"onbloc/internal/logging"
+	"onbloc/internal/migration"
+	"onbloc/internal/repository/postgresdb"
+	"onbloc/pkg/caching"
+	"onbloc/pkg/messaging"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	path := ""
+	flag.StringVar(&path, "c", "config.json", "config path")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		cli.NewApp(admin_cli_config.AdminCLIConfig{}, nil, nil, nil, nil, nil, os.Stdout).Usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	conf, err := admin_cli_config.Load(path)
+	if err != nil {
+		exit(err)
+	}
+
+	// 명령 결과(특히 export)는 stdout 으로 나가므로 로그는 stderr 로 보낸다.
+	log, err := logging.New(os.Stderr, conf.Logging)
+	if err != nil {
+		exit(err)
+	}
+	slog.SetDefault(log.With(logging.KeyService, "onbloc"))
+
+	db, err := gorm.Open(postgres.Open(conf.DB.GetDsn()), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		exit(fmt.Errorf("failed to connect to database: %w", err))
+	}
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		exit(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	messageQueue, err := messaging.NewSQSClient(ctx, conf.MessageQueueUrl)
+	if err != nil {
+		exit(fmt.Errorf("failed to connect to message queue: %w", err))
+	}
+	var deadLetterQueue *messaging.SQSClient
+	if conf.DeadLetterQueueUrl != "" {
+		if deadLetterQueue, err = messaging.NewSQSClient(ctx, conf.DeadLetterQueueUrl); err != nil {
+			exit(fmt.Errorf("failed to connect to dead-letter queue: %w", err))
+		}
+	}
+	var cache caching.Caching
+	if conf.Caching.Host != "" {
+		cache = caching.NewRedisClient(conf.Caching.GetAddr(), conf.Caching.Password, conf.Caching.DB)
+	}
+
+	app := cli.NewApp(conf, postgresdb.NewRepository(db), migrator, messageQueue, deadLetterQueue, cache, os.Stdout)
+	if err = app.Run(ctx, flag.Args()); err != nil {
+		if errors.Is(err, cli.ErrUsage) {
+			fmt.Fprintln(os.Stderr, err)
+			app.Usage(os.Stderr)
+			os.Exit(2)
+		}
+		exit(err)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
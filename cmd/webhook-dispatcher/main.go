@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"log/slog"
+	"onbloc/internal/admin"
+	webhook_dispatcher_config "onbloc/internal/config/webhook-dispatcher"
+	"onbloc/internal/health"
+	"onbloc/internal/logging"
+	"onbloc/internal/migration"
+	"onbloc/internal/repository/postgresdb"
+	webhook_dispatcher "onbloc/internal/service/webhook-dispatcher"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	path := ""
+	flag.StringVar(&path, "c", "config.json", "config path")
+	flag.Parse()
+
+	conf, err := webhook_dispatcher_config.Load(path)
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err = logging.Init(conf.Logging, "webhook-dispatcher"); err != nil {
+		panic(err)
+	}
+
+	db, err := gorm.Open(postgres.Open(conf.DB.GetDsn()), &gorm.Config{})
+	if err != nil {
+		panic(fmt.Sprintf("Failed to connect to database: %s\n", err.Error()))
+	}
+	if err = migration.Check(context.Background(), db); err != nil {
+		panic(err)
+	}
+	repository := postgresdb.NewRepository(db)
+
+	httpClient := webhook_dispatcher.NewHTTPClient(time.Duration(conf.RequestTimeout) * time.Second)
+	service := webhook_dispatcher.NewService(repository, httpClient, time.Duration(conf.PollInterval)*time.Second, conf.BatchSize, conf.MaxAttempts)
+
+	checker := health.NewChecker()
+	if sqlDB, err := db.DB(); err == nil {
+		checker.AddReadiness("postgres", health.Postgres(sqlDB))
+	}
+	checker.AddLiveness("dispatching", service.Heartbeat().Check(conf.Health.GetStallTimeout()))
+	adminServer := admin.Serve(conf.AdminPort, checker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	slog.Info("webhook-dispatcher started")
+	go service.Run(ctx)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+
+	slog.Info("shutting down")
+	cancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	adminServer.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"onbloc/internal/health"
+	"onbloc/internal/logging"
+	"onbloc/internal/metrics"
+	"time"
+)
+
+// Serve 는 API 서버가 없는 바이너리(block-synchronizer, event-processor, webhook-dispatcher)에서 /metrics, /healthz, /readyz 를 제공한다.
+func Serve(port int, checker *health.Checker) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	mux.HandleFunc("GET /healthz", checker.Liveness)
+	mux.HandleFunc("GET /readyz", checker.Readiness)
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("admin server failed", logging.Err(err))
+		}
+	}()
+	return srv
+}
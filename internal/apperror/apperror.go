@@ -0,0 +1,62 @@
+package apperror
+
+import (
+	"errors"
+	"strings"
+)
+
+// Code 는 API 응답에 노출되는 오류 코드이다.
+type Code string
+
+const (
+	CodeNotFound        Code = "NOT_FOUND"
+	CodeInvalidArgument Code = "INVALID_ARGUMENT"
+	CodeUnavailable     Code = "UNAVAILABLE"
+	CodeUnauthenticated Code = "UNAUTHENTICATED"
+	CodeInternal        Code = "INTERNAL"
+)
+
+// repository → service → handler 로 전달되는 도메인 오류. fmt.Errorf("...: %w", ErrNotFound) 처럼 감싸서 사용한다.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrUnavailable     = errors.New("unavailable")
+	ErrUnauthenticated = errors.New("unauthenticated")
+)
+
+func CodeOf(err error) Code {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrInvalidArgument):
+		return CodeInvalidArgument
+	case errors.Is(err, ErrUnavailable):
+		return CodeUnavailable
+	case errors.Is(err, ErrUnauthenticated):
+		return CodeUnauthenticated
+	default:
+		return CodeInternal
+	}
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError 는 필드별 검증 실패 목록이며 ErrInvalidArgument 로 취급된다.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return "validation failed: " + strings.Join(messages, ", ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidArgument
+}
@@ -0,0 +1,21 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCodeOf(t *testing.T) {
+	t.Run("감싼 오류의 코드를 찾는다", func(t *testing.T) {
+		assert.Equal(t, CodeNotFound, CodeOf(fmt.Errorf("block 1: %w", ErrNotFound)))
+		assert.Equal(t, CodeInvalidArgument, CodeOf(fmt.Errorf("invalid cursor: %w", ErrInvalidArgument)))
+		assert.Equal(t, CodeUnavailable, CodeOf(fmt.Errorf("query: %w", ErrUnavailable)))
+		assert.Equal(t, CodeUnauthenticated, CodeOf(fmt.Errorf("api key: %w", ErrUnauthenticated)))
+	})
+
+	t.Run("알 수 없는 오류는 INTERNAL 이다", func(t *testing.T) {
+		assert.Equal(t, CodeInternal, CodeOf(errors.New("boom")))
+	})
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"onbloc/pkg/caching"
+	"text/tabwriter"
+)
+
+func (a App) reconcile(ctx context.Context, args []string) error {
+	fs := newFlagSet("reconcile")
+	chainID := fs.String("chain", "", "chain id (default: first configured chain)")
+	tokenPath := fs.String("token", "", "token path (default: all tokens)")
+	fix := fs.Bool("fix", false, "overwrite mismatched balances with the amounts recomputed from token_events")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	chain, err := a.chain(*chainID)
+	if err != nil {
+		return err
+	}
+
+	mismatches, err := a.repository.FindBalanceMismatches(ctx, chain.ChainID, *tokenPath)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile balances: %w", err)
+	}
+	if len(mismatches) == 0 {
+		fmt.Fprintln(a.out, "balances match token_events")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TOKEN\tADDRESS\tSTORED\tEXPECTED")
+	for _, m := range mismatches {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", m.TokenPath, m.Address, m.Stored, m.Expected)
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	if !*fix {
+		fmt.Fprintf(a.out, "\n%d mismatches (run with --fix to repair)\n", len(mismatches))
+		return nil
+	}
+	for _, m := range mismatches {
+		if err = a.repository.SetBalance(ctx, chain.ChainID, m.TokenPath, m.Address, m.Expected); err != nil {
+			return fmt.Errorf("failed to fix balance of %s/%s: %w", m.TokenPath, m.Address, err)
+		}
+		a.invalidate(ctx, caching.TokenVersionKey(chain.ChainID, m.TokenPath), caching.AddressVersionKey(chain.ChainID, m.Address))
+	}
+	fmt.Fprintf(a.out, "\nfixed %d balances\n", len(mismatches))
+	return nil
+}
+
+func (a App) token(ctx context.Context, args []string) error {
+	_, args, err := subcommand("token", args, "purge")
+	if err != nil {
+		return err
+	}
+
+	fs := newFlagSet("token purge")
+	chainID := fs.String("chain", "", "chain id (default: first configured chain)")
+	tokenPath := fs.String("token", "", "token path to purge")
+	yes := fs.Bool("yes", false, "confirm deletion")
+	if err = fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if *tokenPath == "" {
+		return fmt.Errorf("%w: --token is required", ErrUsage)
+	}
+	if !*yes {
+		return fmt.Errorf("%w: purging deletes all events, balances and volumes of %s; pass --yes to confirm", ErrUsage, *tokenPath)
+	}
+	chain, err := a.chain(*chainID)
+	if err != nil {
+		return err
+	}
+
+	// 삭제 후에는 보유 주소를 알 수 없으므로, 주소별 캐시 무효화 대상을 먼저 모은다.
+	addresses, err := a.holderAddresses(ctx, chain.ChainID, *tokenPath)
+	if err != nil {
+		return err
+	}
+	deleted, err := a.repository.PurgeToken(ctx, chain.ChainID, *tokenPath)
+	if err != nil {
+		return fmt.Errorf("failed to purge token: %w", err)
+	}
+
+	keys := []string{caching.TokenVersionKey(chain.ChainID, *tokenPath)}
+	for _, address := range addresses {
+		keys = append(keys, caching.AddressVersionKey(chain.ChainID, address))
+	}
+	a.invalidate(ctx, keys...)
+	fmt.Fprintf(a.out, "purged %s: %d events, %d balances\n", *tokenPath, deleted, len(addresses))
+	return nil
+}
+
+func (a App) holderAddresses(ctx context.Context, chainID, tokenPath string) (addresses []string, err error) {
+	var afterID int64
+	for {
+		balances, err := a.repository.ListBalances(ctx, chainID, "", tokenPath, afterID, exportPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list balances: %w", err)
+		}
+		for _, balance := range balances {
+			addresses = append(addresses, balance.Address)
+		}
+		if len(balances) < exportPageSize {
+			return addresses, nil
+		}
+		afterID = int64(balances[len(balances)-1].ID)
+	}
+}
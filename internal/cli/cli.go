@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"onbloc/internal/config"
+	admin_cli "onbloc/internal/config/admin-cli"
+	"onbloc/internal/logging"
+	"onbloc/internal/migration"
+	"onbloc/internal/repository/postgresdb"
+	block_synchronizer "onbloc/internal/service/block-synchronizer"
+	"onbloc/internal/tx-indexer"
+	"onbloc/pkg/caching"
+	"onbloc/pkg/messaging"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUsage 는 인자가 잘못되었을 때 반환되며, 호출한 쪽은 사용법을 출력한다.
+var ErrUsage = errors.New("invalid usage")
+
+type command struct {
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+// App 은 onbloc 관리 명령을 실행한다. deadLetterQueue 와 cache 는 설정되지 않았으면 nil 이다.
+type App struct {
+	conf            admin_cli.AdminCLIConfig
+	repository      *postgresdb.Repository
+	migrator        *migration.Migrator
+	messageQueue    *messaging.SQSClient
+	deadLetterQueue *messaging.SQSClient
+	cache           caching.Caching
+	out             io.Writer
+	commands        map[string]command
+}
+
+func NewApp(conf admin_cli.AdminCLIConfig, repository *postgresdb.Repository, migrator *migration.Migrator, messageQueue, deadLetterQueue *messaging.SQSClient, cache caching.Caching, out io.Writer) *App {
+	a := &App{
+		conf:            conf,
+		repository:      repository,
+		migrator:        migrator,
+		messageQueue:    messageQueue,
+		deadLetterQueue: deadLetterQueue,
+		cache:           cache,
+		out:             out,
+	}
+	a.commands = map[string]command{
+		"status":         {"show stored/indexer heights, lag and queue depth", a.status},
+		"resync":         {"re-fetch blocks and transactions from the indexer and republish events", a.resync},
+		"reindex-events": {"re-extract token events from stored transactions and republish them", a.reindexEvents},
+		"reconcile":      {"compare balances with token_events (--fix to overwrite)", a.reconcile},
+		"dlq":            {"list or replay dead-lettered messages (dlq list|replay)", a.dlq},
+		"token":          {"token maintenance (token purge)", a.token},
+		"cursor":         {"move the synchronizer cursor (cursor set)", a.cursor},
+		"export":         {"export balances or token events as csv/json", a.export},
+		"migrate":        {"apply, revert or show schema migrations (migrate status|up|down)", a.migrate},
+	}
+	return a
+}
+
+func (a App) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+	cmd, exists := a.commands[args[0]]
+	if !exists {
+		return fmt.Errorf("%w: unknown command %q", ErrUsage, args[0])
+	}
+	return cmd.run(ctx, args[1:])
+}
+
+func (a App) Usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: onbloc [-c config.json] <command> [flags]")
+	fmt.Fprintln(w, "\ncommands:")
+	names := make([]string, 0, len(a.commands))
+	for name := range a.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n", name, a.commands[name].summary)
+	}
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// subcommand 는 "dlq list" 처럼 두 단계로 이루어진 명령의 하위 명령 이름과 나머지 인자를 나눈다.
+func subcommand(name string, args []string, allowed ...string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("%w: %s requires one of %s", ErrUsage, name, strings.Join(allowed, ", "))
+	}
+	for _, sub := range allowed {
+		if args[0] == sub {
+			return sub, args[1:], nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w: unknown %s command %q", ErrUsage, name, args[0])
+}
+
+// chain 은 chainID 에 해당하는 체인 설정을 찾는다. chainID 가 비어 있으면 첫 번째 체인을 사용한다.
+func (a App) chain(chainID string) (config.Chain, error) {
+	if len(a.conf.Chains) == 0 {
+		return config.Chain{}, errors.New("no chains configured")
+	}
+	if chainID == "" {
+		return a.conf.Chains[0], nil
+	}
+	for _, chain := range a.conf.Chains {
+		if chain.ChainID == chainID {
+			return chain, nil
+		}
+	}
+	return config.Chain{}, fmt.Errorf("unknown chain: %s", chainID)
+}
+
+func (a App) synchronizer(chain config.Chain) *block_synchronizer.Service {
+	client := tx_indexer.NewClient(chain.TxIndexerEndPoint, time.Second*60)
+	return block_synchronizer.NewService(chain.ChainID, client, a.repository, a.messageQueue, a.conf.BackFillBatchSize, 0)
+}
+
+func validateRange(from, to int64) error {
+	if from < 0 || to < 0 {
+		return fmt.Errorf("%w: --from and --to are required", ErrUsage)
+	}
+	if from > to {
+		return fmt.Errorf("%w: --from (%d) is greater than --to (%d)", ErrUsage, from, to)
+	}
+	return nil
+}
+
+// invalidate 는 balance-api 캐시의 버전 키를 올린다. 실패해도 명령은 계속 진행하며, 캐시 항목은 TTL 이 지나면 만료된다.
+func (a App) invalidate(ctx context.Context, keys ...string) {
+	if a.cache == nil {
+		return
+	}
+	for _, key := range keys {
+		if err := a.cache.IncrBy(ctx, key, 1); err != nil {
+			slog.WarnContext(ctx, "failed to invalidate cache", "key", key, logging.Err(err))
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"onbloc/internal/config"
+	admin_cli "onbloc/internal/config/admin-cli"
+	"onbloc/pkg/model"
+	"testing"
+	"time"
+)
+
+func newTestApp(out *bytes.Buffer) *App {
+	conf := admin_cli.AdminCLIConfig{Chains: []config.Chain{{ChainID: "dev"}, {ChainID: "test5"}}}
+	return NewApp(conf, nil, nil, nil, nil, nil, out)
+}
+
+func TestApp_Run(t *testing.T) {
+	t.Run("명령이 없으면 사용법 오류", func(t *testing.T) {
+		err := newTestApp(&bytes.Buffer{}).Run(context.Background(), nil)
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+
+	t.Run("알 수 없는 명령은 사용법 오류", func(t *testing.T) {
+		err := newTestApp(&bytes.Buffer{}).Run(context.Background(), []string{"unknown"})
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+
+	t.Run("범위가 뒤집힌 resync 는 DB 접근 전에 거부", func(t *testing.T) {
+		err := newTestApp(&bytes.Buffer{}).Run(context.Background(), []string{"resync", "--from", "10", "--to", "5"})
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+
+	t.Run("지원하지 않는 reindex-events 모드", func(t *testing.T) {
+		err := newTestApp(&bytes.Buffer{}).Run(context.Background(), []string{"reindex-events", "--from", "1", "--to", "5", "--mode", "unknown"})
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+
+	t.Run("token purge 는 --yes 없이 실행하지 않음", func(t *testing.T) {
+		err := newTestApp(&bytes.Buffer{}).Run(context.Background(), []string{"token", "purge", "--token", "gno.land/r/demo/foo"})
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+
+	t.Run("dlq 하위 명령 누락", func(t *testing.T) {
+		err := newTestApp(&bytes.Buffer{}).Run(context.Background(), []string{"dlq"})
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+
+	t.Run("migrate down 은 --to 와 --yes 없이 실행하지 않음", func(t *testing.T) {
+		err := newTestApp(&bytes.Buffer{}).Run(context.Background(), []string{"migrate", "down", "--to", "1"})
+		assert.ErrorIs(t, err, ErrUsage)
+		err = newTestApp(&bytes.Buffer{}).Run(context.Background(), []string{"migrate", "down", "--yes"})
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+}
+
+func TestApp_Usage(t *testing.T) {
+	out := &bytes.Buffer{}
+	newTestApp(out).Usage(out)
+	for _, name := range []string{"status", "resync", "reindex-events", "reconcile", "dlq", "token", "cursor", "export", "migrate"} {
+		assert.Contains(t, out.String(), name)
+	}
+}
+
+func TestApp_chain(t *testing.T) {
+	app := newTestApp(&bytes.Buffer{})
+
+	t.Run("미지정 시 첫 번째 체인", func(t *testing.T) {
+		chain, err := app.chain("")
+		require.NoError(t, err)
+		assert.Equal(t, "dev", chain.ChainID)
+	})
+
+	t.Run("지정한 체인", func(t *testing.T) {
+		chain, err := app.chain("test5")
+		require.NoError(t, err)
+		assert.Equal(t, "test5", chain.ChainID)
+	})
+
+	t.Run("설정에 없는 체인", func(t *testing.T) {
+		_, err := app.chain("unknown")
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateRange(t *testing.T) {
+	assert.NoError(t, validateRange(1, 1))
+	assert.NoError(t, validateRange(1, 100))
+	assert.ErrorIs(t, validateRange(-1, 100), ErrUsage)
+	assert.ErrorIs(t, validateRange(100, 1), ErrUsage)
+}
+
+func TestSubcommand(t *testing.T) {
+	sub, rest, err := subcommand("dlq", []string{"replay", "--limit", "5"}, "list", "replay")
+	require.NoError(t, err)
+	assert.Equal(t, "replay", sub)
+	assert.Equal(t, []string{"--limit", "5"}, rest)
+
+	_, _, err = subcommand("dlq", []string{"drop"}, "list", "replay")
+	assert.ErrorIs(t, err, ErrUsage)
+}
+
+func TestExporter(t *testing.T) {
+	balance := model.Balance{
+		ID:        1,
+		ChainID:   "dev",
+		Address:   "g1abc",
+		TokenPath: "gno.land/r/demo/foo",
+		Amount:    100,
+		UpdatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	t.Run("csv 는 헤더를 한 번만 쓴다", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		e, err := newExporter(out, "csv")
+		require.NoError(t, err)
+		require.NoError(t, e.write(balanceHeader, balanceRow(balance), balance))
+		require.NoError(t, e.write(balanceHeader, balanceRow(balance), balance))
+		require.NoError(t, e.flush())
+
+		assert.Equal(t, "chain_id,token_path,address,amount,updated_at\n"+
+			"dev,gno.land/r/demo/foo,g1abc,100,2024-01-02T03:04:05Z\n"+
+			"dev,gno.land/r/demo/foo,g1abc,100,2024-01-02T03:04:05Z\n", out.String())
+	})
+
+	t.Run("json 은 한 줄에 객체 하나", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		e, err := newExporter(out, "json")
+		require.NoError(t, err)
+		event := model.TokenEvent{ID: 7, ChainID: "dev", Func: "Mint", To: "g1abc", Amount: 5}
+		require.NoError(t, e.write(tokenEventHeader, tokenEventRow(event), event))
+		require.NoError(t, e.flush())
+
+		assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("\n")))
+		assert.Contains(t, out.String(), `"func":"Mint"`)
+	})
+
+	t.Run("지원하지 않는 형식", func(t *testing.T) {
+		_, err := newExporter(&bytes.Buffer{}, "xml")
+		assert.ErrorIs(t, err, ErrUsage)
+	})
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"onbloc/pkg/messaging"
+)
+
+// sqsMaxMessages 는 SQS 가 한 번에 돌려주는 최대 메시지 수이다.
+const sqsMaxMessages = 10
+
+func (a App) dlq(ctx context.Context, args []string) error {
+	sub, args, err := subcommand("dlq", args, "list", "replay")
+	if err != nil {
+		return err
+	}
+	if a.deadLetterQueue == nil {
+		return errors.New("deadLetterQueueUrl is not configured")
+	}
+
+	fs := newFlagSet("dlq " + sub)
+	limit := fs.Int("limit", 0, "maximum number of messages (default: list 10, replay all)")
+	if err = fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+
+	if sub == "list" {
+		if *limit <= 0 {
+			*limit = sqsMaxMessages
+		}
+		return a.listDeadLetters(ctx, *limit)
+	}
+	return a.replayDeadLetters(ctx, *limit)
+}
+
+// listDeadLetters 는 목록을 다 모을 때까지 받은 메시지를 숨겨 두어 같은 메시지가 다시 나오지 않게 하고, 끝나면 되돌려 놓는다.
+func (a App) listDeadLetters(ctx context.Context, limit int) error {
+	var received []messaging.MessageObject
+	defer func() {
+		for _, message := range received {
+			if err := a.deadLetterQueue.ReleaseMessage(ctx, message); err != nil {
+				fmt.Fprintf(a.out, "failed to release %s: %s\n", message.MessageID, err)
+			}
+		}
+	}()
+
+	for len(received) < limit {
+		messages, err := a.deadLetterQueue.ReceiveMessages(ctx, int32(min(sqsMaxMessages, limit-len(received))))
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		received = append(received, messages...)
+	}
+
+	for _, message := range received {
+		fmt.Fprintf(a.out, "%s\t%s\t%s\n", message.MessageID, message.CreatedTime.Format("2006-01-02T15:04:05Z07:00"), message.JsonData)
+	}
+	fmt.Fprintf(a.out, "%d messages\n", len(received))
+	return nil
+}
+
+// replayDeadLetters 는 메시지를 원래 큐로 다시 보낸 뒤에만 DLQ 에서 삭제한다. 이미 처리된 이벤트는 event-processor 가 중복으로 무시한다.
+func (a App) replayDeadLetters(ctx context.Context, limit int) error {
+	replayed := 0
+	for limit <= 0 || replayed < limit {
+		count := sqsMaxMessages
+		if limit > 0 {
+			count = min(count, limit-replayed)
+		}
+		messages, err := a.deadLetterQueue.ReceiveMessages(ctx, int32(count))
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		for _, message := range messages {
+			if err = a.messageQueue.Republish(ctx, message); err != nil {
+				return fmt.Errorf("failed to replay %s: %w", message.MessageID, err)
+			}
+			if err = a.deadLetterQueue.DeleteMessage(ctx, message); err != nil {
+				return fmt.Errorf("failed to delete %s from dlq: %w", message.MessageID, err)
+			}
+			replayed++
+		}
+	}
+	fmt.Fprintf(a.out, "replayed %d messages\n", replayed)
+	return nil
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"onbloc/pkg/model"
+	"os"
+	"strconv"
+	"time"
+)
+
+const exportPageSize = 1000
+
+var (
+	balanceHeader    = []string{"chain_id", "token_path", "address", "amount", "updated_at"}
+	tokenEventHeader = []string{"id", "chain_id", "transaction_hash", "tx_event_index", "func", "token_path", "from", "to", "amount"}
+)
+
+func (a App) export(ctx context.Context, args []string) error {
+	fs := newFlagSet("export")
+	chainID := fs.String("chain", "", "chain id (default: first configured chain)")
+	kind := fs.String("kind", "balances", "balances or events")
+	format := fs.String("format", "csv", "csv or json (one object per line)")
+	tokenPath := fs.String("token", "", "filter by token path")
+	address := fs.String("address", "", "filter by address")
+	output := fs.String("o", "", "output file (default: stdout)")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	chain, err := a.chain(*chainID)
+	if err != nil {
+		return err
+	}
+
+	w := a.out
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	e, err := newExporter(w, *format)
+	if err != nil {
+		return err
+	}
+
+	switch *kind {
+	case "balances":
+		err = a.exportBalances(ctx, e, chain.ChainID, *address, *tokenPath)
+	case "events":
+		err = a.exportTokenEvents(ctx, e, chain.ChainID, model.TokenEventFilter{Address: *address, TokenPath: *tokenPath})
+	default:
+		return fmt.Errorf("%w: unsupported export kind %q", ErrUsage, *kind)
+	}
+	if err != nil {
+		return err
+	}
+	return e.flush()
+}
+
+func (a App) exportBalances(ctx context.Context, e *exporter, chainID, address, tokenPath string) error {
+	var afterID int64
+	for {
+		balances, err := a.repository.ListBalances(ctx, chainID, address, tokenPath, afterID, exportPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list balances: %w", err)
+		}
+		for _, balance := range balances {
+			if err = e.write(balanceHeader, balanceRow(balance), balance); err != nil {
+				return err
+			}
+		}
+		if len(balances) < exportPageSize {
+			return nil
+		}
+		afterID = int64(balances[len(balances)-1].ID)
+	}
+}
+
+func (a App) exportTokenEvents(ctx context.Context, e *exporter, chainID string, filter model.TokenEventFilter) error {
+	var beforeID int64
+	for {
+		events, err := a.repository.ListTokenEvents(ctx, chainID, filter, beforeID, exportPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list token events: %w", err)
+		}
+		for _, event := range events {
+			if err = e.write(tokenEventHeader, tokenEventRow(event), event); err != nil {
+				return err
+			}
+		}
+		if len(events) < exportPageSize {
+			return nil
+		}
+		beforeID = events[len(events)-1].ID
+	}
+}
+
+func balanceRow(b model.Balance) []string {
+	return []string{b.ChainID, b.TokenPath, b.Address, strconv.FormatInt(b.Amount, 10), b.UpdatedAt.UTC().Format(time.RFC3339)}
+}
+
+func tokenEventRow(e model.TokenEvent) []string {
+	return []string{
+		strconv.FormatInt(e.ID, 10), e.ChainID, e.TransactionHash, strconv.Itoa(e.TxEventIndex),
+		e.Func, e.PkgPath, e.From, e.To, strconv.FormatInt(e.Amount, 10),
+	}
+}
+
+// exporter 는 csv 면 첫 행 앞에 헤더를 쓰고, json 이면 한 줄에 객체 하나씩 쓴다.
+type exporter struct {
+	csv         *csv.Writer
+	json        *json.Encoder
+	wroteHeader bool
+}
+
+func newExporter(w io.Writer, format string) (*exporter, error) {
+	switch format {
+	case "csv":
+		return &exporter{csv: csv.NewWriter(w)}, nil
+	case "json":
+		return &exporter{json: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported export format %q", ErrUsage, format)
+	}
+}
+
+func (e *exporter) write(header, row []string, value interface{}) error {
+	if e.json != nil {
+		return e.json.Encode(value)
+	}
+	if !e.wroteHeader {
+		if err := e.csv.Write(header); err != nil {
+			return err
+		}
+		e.wroteHeader = true
+	}
+	return e.csv.Write(row)
+}
+
+func (e *exporter) flush() error {
+	if e.csv == nil {
+		return nil
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+)
+
+func (a App) migrate(ctx context.Context, args []string) error {
+	sub, args, err := subcommand("migrate", args, "status", "up", "down")
+	if err != nil {
+		return err
+	}
+
+	fs := newFlagSet("migrate " + sub)
+	to := fs.Int("to", -1, "target version (up: default latest, down: required)")
+	chainID := fs.String("chain", "", "chain id for rows created before multi-chain support (up, default: first configured chain)")
+	yes := fs.Bool("yes", false, "confirm reverting migrations (down)")
+	if err = fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+
+	switch sub {
+	case "up":
+		target := *to
+		if target < 0 {
+			target = 0
+		}
+		chain, err := a.chain(*chainID)
+		if err != nil {
+			return err
+		}
+		applied, err := a.migrator.WithDefaultChainID(chain.ChainID).Up(ctx, target)
+		for _, migration := range applied {
+			fmt.Fprintf(a.out, "applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(a.out, "schema is up to date")
+		}
+		return nil
+	case "down":
+		if *to < 0 {
+			return fmt.Errorf("%w: --to is required", ErrUsage)
+		}
+		if !*yes {
+			return fmt.Errorf("%w: reverting migrations may drop tables and data; pass --yes to confirm", ErrUsage)
+		}
+		reverted, err := a.migrator.Down(ctx, *to)
+		for _, migration := range reverted {
+			fmt.Fprintf(a.out, "reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	}
+	return a.migrationStatus(ctx)
+}
+
+func (a App) migrationStatus(ctx context.Context) error {
+	applied, err := a.migrator.Applied(ctx)
+	if err != nil {
+		return err
+	}
+	appliedAt := make(map[int]time.Time, len(applied))
+	for _, migration := range applied {
+		appliedAt[migration.Version] = migration.AppliedAt
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, migration := range a.migrator.Migrations() {
+		at := "pending"
+		if t, ok := appliedAt[migration.Version]; ok {
+			at = t.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", migration.Version, migration.Name, at)
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+
+	version := 0
+	if len(applied) > 0 {
+		version = applied[len(applied)-1].Version
+	}
+	fmt.Fprintf(a.out, "\nschema version: %d, latest: %d\n", version, a.migrator.Latest())
+	return nil
+}
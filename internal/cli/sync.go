@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"onbloc/internal/config"
+	"onbloc/internal/consumer"
+	block_synchronizer "onbloc/internal/service/block-synchronizer"
+	"onbloc/pkg/caching"
+	"text/tabwriter"
+)
+
+func (a App) status(ctx context.Context, args []string) error {
+	fs := newFlagSet("status")
+	chainID := fs.String("chain", "", "chain id (default: all configured chains)")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+
+	chains := a.conf.Chains
+	if *chainID != "" {
+		chain, err := a.chain(*chainID)
+		if err != nil {
+			return err
+		}
+		chains = []config.Chain{chain}
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CHAIN\tLATEST BLOCK\tCURSOR\tINDEXER\tLAG")
+	for _, chain := range chains {
+		service := a.synchronizer(chain)
+		latest, err := a.repository.GetLatestHeight(ctx, chain.ChainID)
+		if err != nil {
+			return fmt.Errorf("failed to get latest block of %s: %w", chain.ChainID, err)
+		}
+		cursor := "-"
+		if height, found, err := a.repository.GetSyncCursor(ctx, chain.ChainID); err != nil {
+			return fmt.Errorf("failed to get cursor of %s: %w", chain.ChainID, err)
+		} else if found {
+			cursor = fmt.Sprint(height)
+		}
+		stored, err := service.GetStoredHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get stored height of %s: %w", chain.ChainID, err)
+		}
+
+		// indexer 에 연결할 수 없어도 DB 쪽 상태는 보여준다.
+		indexer, lag := "unavailable", "-"
+		if height, err := service.GetLatestHeight(ctx); err == nil {
+			indexer, lag = fmt.Sprint(height), fmt.Sprint(height-stored)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", chain.ChainID, latest, cursor, indexer, lag)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "\nqueue depth: %d\n", a.messageQueue.GetMessageCount(ctx))
+	if a.deadLetterQueue != nil {
+		fmt.Fprintf(a.out, "dlq depth:   %d\n", a.deadLetterQueue.GetMessageCount(ctx))
+	}
+	return nil
+}
+
+func (a App) resync(ctx context.Context, args []string) error {
+	fs := newFlagSet("resync")
+	chainID := fs.String("chain", "", "chain id (default: first configured chain)")
+	from := fs.Int64("from", -1, "first height")
+	to := fs.Int64("to", -1, "last height")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if err := validateRange(*from, *to); err != nil {
+		return err
+	}
+	chain, err := a.chain(*chainID)
+	if err != nil {
+		return err
+	}
+
+	if err = a.synchronizer(chain).Resync(ctx, *from, *to); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "resynced %s heights %d..%d\n", chain.ChainID, *from, *to)
+	return nil
+}
+
+// 재추출한 이벤트를 처리하는 방식이다.
+//   - publish: 큐에 발행한다. event-processor 가 새 이벤트만 적용하고 이미 처리한 이벤트는 무시한다.
+//   - apply: 큐를 거치지 않고 event-processor 와 같은 방식으로 바로 적용한다.
+//   - rebuild: 구간의 이벤트를 다시 추출한 이벤트로 바꾼 뒤, 잔액을 새 테이블에 다시 계산해 교체한다.
+//     추출 규칙 수정으로 기존 이벤트가 바뀌거나 없어질 때 사용한다.
+const (
+	reindexModePublish = "publish"
+	reindexModeApply   = "apply"
+	reindexModeRebuild = "rebuild"
+)
+
+func (a App) reindexEvents(ctx context.Context, args []string) error {
+	fs := newFlagSet("reindex-events")
+	chainID := fs.String("chain", "", "chain id (default: first configured chain)")
+	from := fs.Int64("from", -1, "first height")
+	to := fs.Int64("to", -1, "last height")
+	mode := fs.String("mode", reindexModePublish, "publish, apply or rebuild")
+	if err := fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if err := validateRange(*from, *to); err != nil {
+		return err
+	}
+	chain, err := a.chain(*chainID)
+	if err != nil {
+		return err
+	}
+	service := a.synchronizer(chain)
+
+	var handle block_synchronizer.ReplayHandler
+	switch *mode {
+	case reindexModePublish:
+		handle = service.PublishReplayBatch
+	case reindexModeApply:
+		processor := consumer.NewEventProcessor(a.cache, a.messageQueue, a.repository, 0)
+		handle = func(ctx context.Context, batch block_synchronizer.ReplayBatch) error {
+			for _, event := range batch.Events {
+				if err := processor.ProcessEvent(ctx, event); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case reindexModeRebuild:
+		handle = func(ctx context.Context, batch block_synchronizer.ReplayBatch) error {
+			return a.repository.ReplaceTokenEvents(ctx, chain.ChainID, batch.TransactionHashes, batch.Events)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported mode %q", ErrUsage, *mode)
+	}
+
+	replayed, err := service.ReplayStoredEvents(ctx, *from, *to, handle)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "replayed %d events of %s heights %d..%d (%s)\n", replayed, chain.ChainID, *from, *to, *mode)
+	if *mode != reindexModeRebuild {
+		return nil
+	}
+	return a.rebuildBalances(ctx, chain.ChainID)
+}
+
+func (a App) rebuildBalances(ctx context.Context, chainID string) error {
+	// 교체로 바뀌는 잔액은 교체 전 불일치 목록과 같으므로, 이 주소/토큰의 캐시만 무효화한다.
+	mismatches, err := a.repository.FindBalanceMismatches(ctx, chainID, "")
+	if err != nil {
+		return fmt.Errorf("failed to compare balances: %w", err)
+	}
+	if err = a.repository.RebuildBalances(ctx, chainID); err != nil {
+		return fmt.Errorf("failed to rebuild balances: %w", err)
+	}
+	for _, m := range mismatches {
+		a.invalidate(ctx, caching.TokenVersionKey(chainID, m.TokenPath), caching.AddressVersionKey(chainID, m.Address))
+	}
+	fmt.Fprintf(a.out, "rebuilt balances of %s (%d changed)\n", chainID, len(mismatches))
+	return nil
+}
+
+func (a App) cursor(ctx context.Context, args []string) error {
+	_, args, err := subcommand("cursor", args, "set")
+	if err != nil {
+		return err
+	}
+
+	fs := newFlagSet("cursor set")
+	chainID := fs.String("chain", "", "chain id (default: first configured chain)")
+	height := fs.Int64("height", -1, "height the synchronizer resumes from")
+	if err = fs.Parse(args); err != nil {
+		return ErrUsage
+	}
+	if *height < 0 {
+		return fmt.Errorf("%w: --height is required", ErrUsage)
+	}
+	chain, err := a.chain(*chainID)
+	if err != nil {
+		return err
+	}
+
+	if err = a.repository.SetSyncCursor(ctx, chain.ChainID, *height); err != nil {
+		return fmt.Errorf("failed to set cursor: %w", err)
+	}
+	fmt.Fprintf(a.out, "cursor of %s set to %d\n", chain.ChainID, *height)
+	return nil
+}
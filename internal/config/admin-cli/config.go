@@ -0,0 +1,43 @@
+package admin_cli
+
+import (
+	"fmt"
+	"onbloc/internal/config"
+)
+
+// AdminCLIConfig 는 onbloc 관리 명령의 설정이다. Caching 의 Host 가 비어 있으면 잔액 캐시를 무효화하지 않는다.
+type AdminCLIConfig struct {
+	Chains             []config.Chain  `json:"chains"`
+	BackFillBatchSize  int             `json:"backFillBatchSize"`
+	MessageQueueUrl    string          `json:"messageQueueUrl"`
+	DeadLetterQueueUrl string          `json:"deadLetterQueueUrl"`
+	Caching            config.Redis    `json:"caching"`
+	DB                 config.Database `json:"db"`
+	Logging            config.Logging  `json:"logging"`
+}
+
+func (c AdminCLIConfig) Validate() error {
+	v := &config.Validation{}
+	if len(c.Chains) == 0 {
+		v.Addf("chains is required")
+	}
+	for i, chain := range c.Chains {
+		chain.Check(v, fmt.Sprintf("chains[%d]", i))
+	}
+	v.Positive("backFillBatchSize", c.BackFillBatchSize)
+	v.URL("messageQueueUrl", c.MessageQueueUrl)
+	if c.DeadLetterQueueUrl != "" {
+		v.URL("deadLetterQueueUrl", c.DeadLetterQueueUrl)
+	}
+	if c.Caching.Host != "" {
+		c.Caching.Check(v, "caching")
+	}
+	c.DB.Check(v, "db")
+	c.Logging.Check(v, "logging")
+	return v.Err()
+}
+
+func Load(path string) (conf AdminCLIConfig, err error) {
+	err = config.Load(path, &conf)
+	return
+}
@@ -1,31 +1,43 @@
 package balance_api
 
 import (
-	"encoding/json"
-	"log"
+	"fmt"
 	"onbloc/internal/config"
-	"os"
 )
 
 type BalanceAPIConfig struct {
-	Port int
-	DB   config.Database
+	Port           int             `json:"port"`
+	GrpcPort       int             `json:"grpcPort"`
+	ChainID        string          `json:"chainId"`
+	WebhookAPIKeys []string        `json:"webhookApiKeys"`
+	DB             config.Database `json:"db"`
+	Cache          config.Cache    `json:"cache"`
+	Tracing        config.Tracing  `json:"tracing"`
+	Logging        config.Logging  `json:"logging"`
 }
 
-func Load(path string) (config BalanceAPIConfig, err error) {
-	if _, err = os.Stat(path); os.IsNotExist(err) {
-		log.Println(err)
-		return
+// Validate 에서 grpcPort 는 0(gRPC 미사용)을 허용한다. webhookApiKeys 는 /webhooks 를 여는 X-API-Key 값들로, 하나 이상 필요하다.
+func (c BalanceAPIConfig) Validate() error {
+	v := &config.Validation{}
+	v.Port("port", c.Port)
+	if c.GrpcPort != 0 {
+		v.Port("grpcPort", c.GrpcPort)
 	}
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return
+	v.Required("chainId", c.ChainID)
+	if len(c.WebhookAPIKeys) == 0 {
+		v.Addf("webhookApiKeys is required")
 	}
-	err = json.Unmarshal(data, &config)
-	if err != nil {
-		return
+	for i, key := range c.WebhookAPIKeys {
+		v.Required(fmt.Sprintf("webhookApiKeys[%d]", i), key)
 	}
+	c.DB.Check(v, "db")
+	c.Cache.Check(v, "cache")
+	c.Tracing.Check(v, "tracing")
+	c.Logging.Check(v, "logging")
+	return v.Err()
+}
 
+func Load(path string) (conf BalanceAPIConfig, err error) {
+	err = config.Load(path, &conf)
 	return
 }
@@ -1,35 +1,62 @@
 package block_synchronizer
 
 import (
-	"encoding/json"
-	"log"
+	"fmt"
 	"onbloc/internal/config"
-	"os"
 )
 
 type BlockSynchronizerConfig struct {
-	TxIndexerEndPoint string          `json:"txIndexerEndPoint"`
-	BackFillBatchSize int             `json:"backFillBatchSize"`
-	SyncInterval      int             `json:"syncInterval"`
-	MessageQueueUrl   string          `json:"messageQueueUrl"`
-	DB                config.Database `json:"db"`
+	ChainID           string                `json:"chainId"`
+	TxIndexerEndPoint string                `json:"txIndexerEndPoint"`
+	Chains            []config.Chain        `json:"chains"`
+	BackFillBatchSize int                   `json:"backFillBatchSize"`
+	SyncInterval      int                   `json:"syncInterval"`
+	MessageQueueUrl   string                `json:"messageQueueUrl"`
+	AdminPort         int                   `json:"adminPort"`
+	Health            config.Health         `json:"health"`
+	Shutdown          config.Shutdown       `json:"shutdown"`
+	LeaderElection    config.LeaderElection `json:"leaderElection"`
+	Tracing           config.Tracing        `json:"tracing"`
+	Logging           config.Logging        `json:"logging"`
+	DB                config.Database       `json:"db"`
 }
 
-func Load(path string) (config BlockSynchronizerConfig, err error) {
-	if _, err = os.Stat(path); os.IsNotExist(err) {
-		log.Println(err)
-		return
-	}
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return
+// GetChains 는 chains 가 비어 있으면 단일 체인 설정(chainId, txIndexerEndPoint)을 사용한다.
+func (c BlockSynchronizerConfig) GetChains() []config.Chain {
+	if len(c.Chains) > 0 {
+		return c.Chains
 	}
+	return []config.Chain{{ChainID: c.ChainID, TxIndexerEndPoint: c.TxIndexerEndPoint}}
+}
 
-	err = json.Unmarshal(data, &config)
-	if err != nil {
-		return
+func (c BlockSynchronizerConfig) Validate() error {
+	v := &config.Validation{}
+	seen := make(map[string]bool)
+	for i, chain := range c.GetChains() {
+		field := fmt.Sprintf("chains[%d]", i)
+		if len(c.Chains) == 0 {
+			field = ""
+		}
+		chain.Check(v, field)
+		if chain.ChainID != "" && seen[chain.ChainID] {
+			v.Addf("%s.chainId is duplicated: %q", field, chain.ChainID)
+		}
+		seen[chain.ChainID] = true
 	}
+	v.Positive("backFillBatchSize", c.BackFillBatchSize)
+	v.Positive("syncInterval", c.SyncInterval)
+	v.URL("messageQueueUrl", c.MessageQueueUrl)
+	v.Port("adminPort", c.AdminPort)
+	c.Health.Check(v, "health")
+	c.Shutdown.Check(v, "shutdown")
+	c.LeaderElection.Check(v, "leaderElection")
+	c.Tracing.Check(v, "tracing")
+	c.Logging.Check(v, "logging")
+	c.DB.Check(v, "db")
+	return v.Err()
+}
 
+func Load(path string) (conf BlockSynchronizerConfig, err error) {
+	err = config.Load(path, &conf)
 	return
 }
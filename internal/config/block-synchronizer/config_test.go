@@ -0,0 +1,39 @@
+package block_synchronizer
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"onbloc/internal/config"
+	"testing"
+)
+
+func TestBlockSynchronizerConfig_GetChains(t *testing.T) {
+	t.Run("chains 미설정 시 단일 체인 설정 사용", func(t *testing.T) {
+		conf := BlockSynchronizerConfig{ChainID: "dev", TxIndexerEndPoint: "http://localhost:8546/graphql/query"}
+		assert.Equal(t, []config.Chain{{ChainID: "dev", TxIndexerEndPoint: "http://localhost:8546/graphql/query"}}, conf.GetChains())
+	})
+
+	t.Run("chains 설정 시 chains 우선", func(t *testing.T) {
+		chains := []config.Chain{
+			{ChainID: "dev", TxIndexerEndPoint: "http://dev/graphql/query"},
+			{ChainID: "test5", TxIndexerEndPoint: "http://test5/graphql/query"},
+		}
+		conf := BlockSynchronizerConfig{ChainID: "ignored", Chains: chains}
+		assert.Equal(t, chains, conf.GetChains())
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("저장소의 설정 파일은 검사를 통과한다", func(t *testing.T) {
+		conf, err := Load("../../../cmd/block-synchronizer/config.json")
+		require.NoError(t, err)
+		assert.Equal(t, 5000, conf.BackFillBatchSize)
+	})
+
+	t.Run("환경 변수로 덮어쓴 값도 검사한다", func(t *testing.T) {
+		t.Setenv("ONBLOC_BACK_FILL_BATCH_SIZE", "0")
+		t.Setenv("ONBLOC_CHAINS_0_TX_INDEXER_END_POINT", "dev-indexer")
+		_, err := Load("../../../cmd/block-synchronizer/config.json")
+		assert.EqualError(t, err, `invalid config: chains[0].txIndexerEndPoint must be an absolute URL: "dev-indexer"; backFillBatchSize must be positive: 0`)
+	})
+}
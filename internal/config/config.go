@@ -1,31 +1,135 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type Database struct {
-	Driver   string
-	Host     string
-	User     string
-	Port     int
-	Password string
-	DBName   string
-	SSLMode  string
+	Driver   string `json:"driver"`
+	Host     string `json:"host"`
+	User     string `json:"user"`
+	Port     int    `json:"port"`
+	Password string `json:"password"`
+	DBName   string `json:"dbname"`
+	SSLMode  string `json:"sslMode"`
 }
 
 func (d Database) GetDsn() string {
 	if d.SSLMode == "" {
-		d.SSLMode = "disable"
+		d.SSLMode = DefaultSSLMode
 	}
 	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s", d.Host, d.User, d.Password, d.DBName, d.Port, d.SSLMode)
 }
 
 type Redis struct {
-	Host     string
-	Port     int
-	DB       int
-	Password string
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	DB       int    `json:"db"`
+	Password string `json:"password"`
 }
 
 func (r Redis) GetAddr() string {
 	return fmt.Sprintf("%s:%d", r.Host, r.Port)
 }
+
+// Cache 는 balance-api 의 응답 캐시 설정이다. Driver 는 "redis", "lru" 또는 빈 값(캐시 미사용)이다.
+type Cache struct {
+	Driver string `json:"driver"`
+	Redis  Redis  `json:"redis"`
+	Size   int    `json:"size"`
+	TTL    int    `json:"ttl"`
+}
+
+func (c Cache) GetSize() int {
+	if c.Size <= 0 {
+		return DefaultCacheSize
+	}
+	return c.Size
+}
+
+func (c Cache) GetTTL() time.Duration {
+	if c.TTL <= 0 {
+		return DefaultCacheTTL
+	}
+	return time.Duration(c.TTL) * time.Second
+}
+
+// Health 는 liveness 판단 기준이다. StallTimeout 은 초 단위이며, MaxLagBlocks 가 0 이면 동기화 지연은 확인하지 않는다.
+type Health struct {
+	MaxLagBlocks int64 `json:"maxLagBlocks"`
+	StallTimeout int   `json:"stallTimeout"`
+}
+
+func (h Health) GetStallTimeout() time.Duration {
+	if h.StallTimeout <= 0 {
+		return DefaultStallTimeout
+	}
+	return time.Duration(h.StallTimeout) * time.Second
+}
+
+// Shutdown 은 종료 신호를 받은 뒤 처리 중인 작업을 기다리는 시간(초)이다. 지나면 작업을 마치지 않고 종료한다.
+type Shutdown struct {
+	Timeout int `json:"timeout"`
+}
+
+func (s Shutdown) GetTimeout() time.Duration {
+	if s.Timeout <= 0 {
+		return DefaultShutdownTimeout
+	}
+	return time.Duration(s.Timeout) * time.Second
+}
+
+// LeaderElection 은 block-synchronizer 리더 임대 설정이다. LeaseTTL(초) 안에 연장하지 못한 리더는 물러나고,
+// 대기 중인 인스턴스가 LeaseTTL/3 간격으로 임대를 시도해 이어받는다.
+type LeaderElection struct {
+	LeaseTTL int `json:"leaseTtl"`
+}
+
+func (l LeaderElection) GetLeaseTTL() time.Duration {
+	if l.LeaseTTL <= 0 {
+		return DefaultLeaseTTL
+	}
+	return time.Duration(l.LeaseTTL) * time.Second
+}
+
+// Tracing 은 OpenTelemetry 트레이스 내보내기 설정이다. Exporter 는 "otlp", "stdout" 또는 빈 값(내보내지 않음)이다.
+type Tracing struct {
+	Exporter    string  `json:"exporter"`
+	Endpoint    string  `json:"endpoint"`
+	Insecure    bool    `json:"insecure"`
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+func (t Tracing) GetSampleRatio() float64 {
+	if t.SampleRatio <= 0 {
+		return DefaultSampleRatio
+	}
+	return t.SampleRatio
+}
+
+// Logging 은 로그 출력 설정이다. Level 은 debug/info/warn/error(기본 info), Format 은 json/text(기본 text)이다.
+type Logging struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+}
+
+func (l Logging) GetLevel() string {
+	if l.Level == "" {
+		return DefaultLogLevel
+	}
+	return l.Level
+}
+
+func (l Logging) GetFormat() string {
+	if l.Format == "" {
+		return DefaultLogFormat
+	}
+	return strings.ToLower(l.Format)
+}
+
+type Chain struct {
+	ChainID           string `json:"chainId"`
+	TxIndexerEndPoint string `json:"txIndexerEndPoint"`
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+// 설정 값이 0 이거나 비어 있을 때 쓰는 기본값이다. 기본값은 여기에만 두고, 각 설정의 Get 메서드가 이를 사용한다.
+const (
+	// DefaultSSLMode 는 db.sslMode 의 기본값이다.
+	DefaultSSLMode = "disable"
+	// DefaultStallTimeout 은 health.stallTimeout 의 기본값이다.
+	DefaultStallTimeout = 5 * time.Minute
+	// DefaultShutdownTimeout 은 shutdown.timeout 의 기본값이다.
+	DefaultShutdownTimeout = 30 * time.Second
+	// DefaultLeaseTTL 은 leaderElection.leaseTtl 의 기본값이다.
+	DefaultLeaseTTL = 15 * time.Second
+	// DefaultCacheTTL 은 balance-api cache.ttl 의 기본값이다.
+	DefaultCacheTTL = time.Minute
+	// DefaultCacheSize 는 cache.driver 가 lru 일 때 cache.size 의 기본값이다.
+	DefaultCacheSize = 10000
+	// DefaultSampleRatio 는 tracing.sampleRatio 의 기본값이다.
+	DefaultSampleRatio = 1.0
+	// DefaultLogLevel, DefaultLogFormat 은 logging.level, logging.format 의 기본값이다.
+	DefaultLogLevel  = "info"
+	DefaultLogFormat = "text"
+)
@@ -1,10 +1,7 @@
 package event_processor
 
 import (
-	"encoding/json"
-	"log"
 	"onbloc/internal/config"
-	"os"
 )
 
 type EventProcessorConfig struct {
@@ -12,23 +9,28 @@ type EventProcessorConfig struct {
 	Caching         config.Redis    `json:"caching"`
 	DB              config.Database `json:"db"`
 	BatchSize       int             `json:"batchSize"`
+	AdminPort       int             `json:"adminPort"`
+	Health          config.Health   `json:"health"`
+	Shutdown        config.Shutdown `json:"shutdown"`
+	Tracing         config.Tracing  `json:"tracing"`
+	Logging         config.Logging  `json:"logging"`
 }
 
-func Load(path string) (config EventProcessorConfig, err error) {
-	if _, err = os.Stat(path); os.IsNotExist(err) {
-		log.Println(err)
-		return
-	}
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return
-	}
-
-	err = json.Unmarshal(data, &config)
-	if err != nil {
-		return
-	}
+func (c EventProcessorConfig) Validate() error {
+	v := &config.Validation{}
+	v.URL("messageQueueUrl", c.MessageQueueUrl)
+	c.Caching.Check(v, "caching")
+	c.DB.Check(v, "db")
+	v.Positive("batchSize", c.BatchSize)
+	v.Port("adminPort", c.AdminPort)
+	c.Health.Check(v, "health")
+	c.Shutdown.Check(v, "shutdown")
+	c.Tracing.Check(v, "tracing")
+	c.Logging.Check(v, "logging")
+	return v.Err()
+}
 
+func Load(path string) (conf EventProcessorConfig, err error) {
+	err = config.Load(path, &conf)
 	return
 }
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix 는 설정 값을 덮어쓰는 환경 변수의 접두사이다.
+const EnvPrefix = "ONBLOC_"
+
+// Validator 는 Load 가 파일과 환경 변수를 반영한 뒤 호출하는 설정 검사이다.
+type Validator interface {
+	Validate() error
+}
+
+// Load 는 path 의 JSON(.json) 또는 YAML(.yaml, .yml) 설정을 target 에 읽고, ONBLOC_* 환경 변수로 덮어쓴 뒤 검사한다.
+// 파일이 없거나 모르는 키가 있으면 오류를 반환한다.
+func Load(path string, target any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	if err = decode(path, data, target); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if err = ApplyEnv(target, os.LookupEnv); err != nil {
+		return err
+	}
+	if validator, ok := target.(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// decode 는 YAML 도 JSON 으로 바꿔 읽어, 두 형식 모두 json 태그를 키로 쓰고 모르는 키를 거부하게 한다.
+func decode(path string, data []byte, target any) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+	case ".yaml", ".yml":
+		var document any
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return err
+		}
+		if document == nil {
+			document = map[string]any{}
+		}
+		converted, err := json.Marshal(document)
+		if err != nil {
+			return err
+		}
+		data = converted
+	default:
+		return fmt.Errorf("unsupported config format %q (use .json, .yaml or .yml)", filepath.Ext(path))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+// ApplyEnv 는 target 의 각 값 필드를 ONBLOC_<키 경로> 환경 변수로 덮어쓴다.
+// 키 경로는 json 태그를 대문자 스네이크로 바꿔 _ 로 이은 것이며(db.sslMode → ONBLOC_DB_SSL_MODE),
+// 배열은 이미 있는 원소만 번호로 지정한다(ONBLOC_CHAINS_0_TX_INDEXER_END_POINT).
+// <이름>_FILE 을 지정하면 그 파일의 내용을 값으로 쓴다(비밀번호 등).
+func ApplyEnv(target any, lookup func(string) (string, bool)) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config target must be a pointer to struct, got %T", target)
+	}
+	return applyEnv(value.Elem(), strings.TrimSuffix(EnvPrefix, "_"), lookup)
+}
+
+func applyEnv(value reflect.Value, name string, lookup func(string) (string, bool)) error {
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			key := fieldKey(field)
+			if key == "-" {
+				continue
+			}
+			if err := applyEnv(value.Field(i), name+"_"+envName(key), lookup); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			if err := applyEnv(value.Index(i), name+"_"+strconv.Itoa(i), lookup); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	raw, ok, err := lookupEnv(name, lookup)
+	if err != nil || !ok {
+		return err
+	}
+	if err = setValue(value, raw); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+func lookupEnv(name string, lookup func(string) (string, bool)) (string, bool, error) {
+	raw, ok := lookup(name)
+	secretPath, fromFile := lookup(name + "_FILE")
+	switch {
+	case ok && fromFile:
+		return "", false, fmt.Errorf("both %s and %s_FILE are set", name, name)
+	case fromFile:
+		data, err := os.ReadFile(secretPath)
+		if err != nil {
+			return "", false, fmt.Errorf("%s_FILE: %w", name, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), true, nil
+	}
+	return raw, ok, nil
+}
+
+func setValue(value reflect.Value, raw string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", raw)
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		value.SetInt(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", value.Type())
+	}
+	return nil
+}
+
+func fieldKey(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("json"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// envName 은 messageQueueUrl, DBName 같은 키를 MESSAGE_QUEUE_URL, DB_NAME 으로 바꾼다.
+func envName(key string) string {
+	runes := []rune(key)
+	var builder strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				builder.WriteByte('_')
+			}
+		}
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+	return builder.String()
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testConfig struct {
+	MessageQueueUrl string   `json:"messageQueueUrl"`
+	BatchSize       int      `json:"batchSize"`
+	DB              Database `json:"db"`
+	Chains          []Chain  `json:"chains"`
+	Tracing         Tracing  `json:"tracing"`
+}
+
+func (c testConfig) Validate() error {
+	v := &Validation{}
+	v.URL("messageQueueUrl", c.MessageQueueUrl)
+	v.Positive("batchSize", c.BatchSize)
+	c.DB.Check(v, "db")
+	return v.Err()
+}
+
+const testJSON = `{
+  "messageQueueUrl": "http://localhost:4566/000000000000/event-queue",
+  "batchSize": 100,
+  "db": {"host": "localhost", "user": "postgres", "port": 5432, "password": "password", "dbname": "onbloc"},
+  "chains": [{"chainId": "dev", "txIndexerEndPoint": "http://dev/graphql/query"}]
+}`
+
+const testYAML = `
+messageQueueUrl: http://localhost:4566/000000000000/event-queue
+batchSize: 100
+db:
+  host: localhost
+  user: postgres
+  port: 5432
+  password: password
+  dbname: onbloc
+chains:
+  - chainId: dev
+    txIndexerEndPoint: http://dev/graphql/query
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func mapLookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("JSON 과 YAML 은 같은 설정으로 읽힌다", func(t *testing.T) {
+		var fromJSON, fromYAML testConfig
+		require.NoError(t, Load(writeFile(t, "config.json", testJSON), &fromJSON))
+		require.NoError(t, Load(writeFile(t, "config.yaml", testYAML), &fromYAML))
+		assert.Equal(t, fromJSON, fromYAML)
+		assert.Equal(t, "onbloc", fromYAML.DB.DBName)
+	})
+
+	t.Run("파일이 없으면 오류를 반환한다", func(t *testing.T) {
+		var conf testConfig
+		err := Load(filepath.Join(t.TempDir(), "missing.json"), &conf)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("모르는 키는 거부한다", func(t *testing.T) {
+		var conf testConfig
+		err := Load(writeFile(t, "config.yml", testYAML+"batchSzie: 10\n"), &conf)
+		assert.ErrorContains(t, err, "batchSzie")
+	})
+
+	t.Run("지원하지 않는 확장자는 거부한다", func(t *testing.T) {
+		var conf testConfig
+		assert.ErrorContains(t, Load(writeFile(t, "config.toml", ""), &conf), "unsupported config format")
+	})
+
+	t.Run("검사에서 찾은 문제를 모두 알려준다", func(t *testing.T) {
+		var conf testConfig
+		err := Load(writeFile(t, "config.json", `{"batchSize": 0, "db": {"host": "localhost", "user": "postgres", "port": 5432}}`), &conf)
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, []string{
+			"messageQueueUrl is required",
+			"batchSize must be positive: 0",
+			"db.dbname is required",
+		}, validationErr.Problems)
+	})
+}
+
+func TestApplyEnv(t *testing.T) {
+	base := func(t *testing.T) testConfig {
+		var conf testConfig
+		require.NoError(t, decode("config.json", []byte(testJSON), &conf))
+		return conf
+	}
+
+	t.Run("키 경로의 환경 변수로 덮어쓴다", func(t *testing.T) {
+		conf := base(t)
+		err := ApplyEnv(&conf, mapLookup(map[string]string{
+			"ONBLOC_MESSAGE_QUEUE_URL":                 "http://sqs/queue",
+			"ONBLOC_BATCH_SIZE":                        "7",
+			"ONBLOC_DB_SSL_MODE":                       "require",
+			"ONBLOC_CHAINS_0_TX_INDEXER_END_POINT":     "http://override/graphql/query",
+			"ONBLOC_TRACING_INSECURE":                  "true",
+			"ONBLOC_TRACING_SAMPLE_RATIO":              "0.5",
+			"ONBLOC_CHAINS_1_TX_INDEXER_END_POINT":     "http://ignored",
+			"ONBLOC_UNRELATED_KEY_IS_IGNORED_SILENTLY": "x",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "http://sqs/queue", conf.MessageQueueUrl)
+		assert.Equal(t, 7, conf.BatchSize)
+		assert.Equal(t, "require", conf.DB.SSLMode)
+		assert.Equal(t, []Chain{{ChainID: "dev", TxIndexerEndPoint: "http://override/graphql/query"}}, conf.Chains)
+		assert.True(t, conf.Tracing.Insecure)
+		assert.Equal(t, 0.5, conf.Tracing.SampleRatio)
+	})
+
+	t.Run("_FILE 로 지정한 파일의 내용을 값으로 쓴다", func(t *testing.T) {
+		conf := base(t)
+		secret := writeFile(t, "db-password", "s3cret\n")
+		require.NoError(t, ApplyEnv(&conf, mapLookup(map[string]string{"ONBLOC_DB_PASSWORD_FILE": secret})))
+		assert.Equal(t, "s3cret", conf.DB.Password)
+	})
+
+	t.Run("값과 _FILE 을 함께 지정하면 오류이다", func(t *testing.T) {
+		conf := base(t)
+		err := ApplyEnv(&conf, mapLookup(map[string]string{
+			"ONBLOC_DB_PASSWORD":      "a",
+			"ONBLOC_DB_PASSWORD_FILE": "/run/secrets/db",
+		}))
+		assert.ErrorContains(t, err, "both ONBLOC_DB_PASSWORD and ONBLOC_DB_PASSWORD_FILE are set")
+	})
+
+	t.Run("형식이 맞지 않으면 변수 이름과 함께 알려준다", func(t *testing.T) {
+		conf := base(t)
+		err := ApplyEnv(&conf, mapLookup(map[string]string{"ONBLOC_DB_PORT": "five"}))
+		assert.EqualError(t, err, `ONBLOC_DB_PORT: invalid integer "five"`)
+	})
+}
+
+func TestEnvName(t *testing.T) {
+	cases := map[string]string{
+		"messageQueueUrl":   "MESSAGE_QUEUE_URL",
+		"txIndexerEndPoint": "TX_INDEXER_END_POINT",
+		"leaseTtl":          "LEASE_TTL",
+		"dbname":            "DBNAME",
+		"DBName":            "DB_NAME",
+		"GrpcPort":          "GRPC_PORT",
+		"ChainID":           "CHAIN_ID",
+	}
+	for key, expected := range cases {
+		assert.Equal(t, expected, envName(key), key)
+	}
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// ValidationError 는 설정 검사에서 찾은 문제를 모두 담는다. 항목 이름은 설정 파일의 키 경로(db.host 등)이다.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config: " + strings.Join(e.Problems, "; ")
+}
+
+// Validation 은 설정 항목을 차례로 검사하며 문제를 모은다.
+type Validation struct {
+	problems []string
+}
+
+func (v *Validation) Addf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *Validation) Required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.Addf("%s is required", field)
+	}
+}
+
+// URL 은 scheme 과 host 를 갖춘 URL 인지 확인한다.
+func (v *Validation) URL(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.Addf("%s is required", field)
+		return
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		v.Addf("%s must be an absolute URL: %q", field, value)
+	}
+}
+
+func (v *Validation) Positive(field string, value int) {
+	if value <= 0 {
+		v.Addf("%s must be positive: %d", field, value)
+	}
+}
+
+// NonNegative 는 0(기본값 사용)을 허용하고 음수만 거부한다.
+func (v *Validation) NonNegative(field string, value int64) {
+	if value < 0 {
+		v.Addf("%s must not be negative: %d", field, value)
+	}
+}
+
+func (v *Validation) Port(field string, value int) {
+	if value <= 0 || value > 65535 {
+		v.Addf("%s must be a port between 1 and 65535: %d", field, value)
+	}
+}
+
+func (v *Validation) OneOf(field, value string, allowed ...string) {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return
+		}
+	}
+	v.Addf("%s must be one of %q: %q", field, allowed, value)
+}
+
+func (v *Validation) Err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func (d Database) Check(v *Validation, field string) {
+	if d.Driver != "" {
+		v.OneOf(field+".driver", d.Driver, "postgres")
+	}
+	v.Required(field+".host", d.Host)
+	v.Required(field+".user", d.User)
+	v.Required(field+".dbname", d.DBName)
+	v.Port(field+".port", d.Port)
+}
+
+func (r Redis) Check(v *Validation, field string) {
+	v.Required(field+".host", r.Host)
+	v.Port(field+".port", r.Port)
+	v.NonNegative(field+".db", int64(r.DB))
+}
+
+func (c Cache) Check(v *Validation, field string) {
+	v.OneOf(field+".driver", c.Driver, "", "redis", "lru")
+	if c.Driver == "redis" {
+		c.Redis.Check(v, field+".redis")
+	}
+	v.NonNegative(field+".size", int64(c.Size))
+	v.NonNegative(field+".ttl", int64(c.TTL))
+}
+
+func (h Health) Check(v *Validation, field string) {
+	v.NonNegative(field+".maxLagBlocks", h.MaxLagBlocks)
+	v.NonNegative(field+".stallTimeout", int64(h.StallTimeout))
+}
+
+func (s Shutdown) Check(v *Validation, field string) {
+	v.NonNegative(field+".timeout", int64(s.Timeout))
+}
+
+func (l LeaderElection) Check(v *Validation, field string) {
+	v.NonNegative(field+".leaseTtl", int64(l.LeaseTTL))
+}
+
+func (t Tracing) Check(v *Validation, field string) {
+	v.OneOf(field+".exporter", t.Exporter, "", "otlp", "stdout")
+	if t.Exporter == "otlp" {
+		v.Required(field+".endpoint", t.Endpoint)
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		v.Addf("%s.sampleRatio must be between 0 and 1: %v", field, t.SampleRatio)
+	}
+}
+
+func (l Logging) Check(v *Validation, field string) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(l.GetLevel())); err != nil {
+		v.Addf("%s.level must be one of debug, info, warn, error: %q", field, l.Level)
+	}
+	v.OneOf(field+".format", l.GetFormat(), "json", "text")
+}
+
+// Check 의 field 가 비어 있으면 최상위 키(chainId, txIndexerEndPoint)로 보고한다.
+func (c Chain) Check(v *Validation, field string) {
+	prefix := ""
+	if field != "" {
+		prefix = field + "."
+	}
+	v.Required(prefix+"chainId", c.ChainID)
+	v.URL(prefix+"txIndexerEndPoint", c.TxIndexerEndPoint)
+}
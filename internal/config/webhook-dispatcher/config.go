@@ -0,0 +1,34 @@
+package webhook_dispatcher
+
+import (
+	"onbloc/internal/config"
+)
+
+type WebhookDispatcherConfig struct {
+	PollInterval   int             `json:"pollInterval"`
+	BatchSize      int             `json:"batchSize"`
+	MaxAttempts    int             `json:"maxAttempts"`
+	RequestTimeout int             `json:"requestTimeout"`
+	AdminPort      int             `json:"adminPort"`
+	Health         config.Health   `json:"health"`
+	DB             config.Database `json:"db"`
+	Logging        config.Logging  `json:"logging"`
+}
+
+func (c WebhookDispatcherConfig) Validate() error {
+	v := &config.Validation{}
+	v.Positive("pollInterval", c.PollInterval)
+	v.Positive("batchSize", c.BatchSize)
+	v.Positive("maxAttempts", c.MaxAttempts)
+	v.Positive("requestTimeout", c.RequestTimeout)
+	v.Port("adminPort", c.AdminPort)
+	c.Health.Check(v, "health")
+	c.DB.Check(v, "db")
+	c.Logging.Check(v, "logging")
+	return v.Err()
+}
+
+func Load(path string) (conf WebhookDispatcherConfig, err error) {
+	err = config.Load(path, &conf)
+	return
+}
@@ -0,0 +1,90 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"onbloc/internal/repository/postgresdb"
+	block_synchronizer "onbloc/internal/service/block-synchronizer"
+	"onbloc/pkg/model"
+	"time"
+)
+
+// VolumeAggregator 는 처리된 토큰 이벤트를 시간 버킷별 롤업(token_volumes)에 반영한다.
+type VolumeAggregator struct {
+	repository *postgresdb.Repository
+}
+
+func NewVolumeAggregator(repository *postgresdb.Repository) *VolumeAggregator {
+	return &VolumeAggregator{repository: repository}
+}
+
+func (a VolumeAggregator) Aggregate(ctx context.Context, tx *gorm.DB, event model.TokenEvent) error {
+	// 블록 시간을 모르면 버킷을 정할 수 없으므로, 메시지가 재시도되거나 DLQ 로 가도록 실패시킨다.
+	eventTime, err := a.repository.GetTransactionBlockTime(ctx, tx, event.ChainID, event.TransactionHash)
+	if err != nil {
+		return fmt.Errorf("block time of transaction %s: %w", event.TransactionHash, err)
+	}
+
+	for interval, duration := range model.VolumeIntervals {
+		bucketStart := BucketStart(eventTime, duration)
+		volume := NewTokenVolume(event, interval, bucketStart)
+
+		if event.Func == block_synchronizer.EventFuncTransfer {
+			volume.UniqueSenders, err = a.addParticipant(ctx, tx, volume, model.VolumeRoleSender, event.From)
+			if err != nil {
+				return err
+			}
+			volume.UniqueReceivers, err = a.addParticipant(ctx, tx, volume, model.VolumeRoleReceiver, event.To)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = a.repository.UpsertTokenVolume(ctx, tx, volume); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a VolumeAggregator) addParticipant(ctx context.Context, tx *gorm.DB, volume model.TokenVolume, role, address string) (int64, error) {
+	inserted, err := a.repository.InsertVolumeParticipant(ctx, tx, model.TokenVolumeParticipant{
+		ChainID:     volume.ChainID,
+		TokenPath:   volume.TokenPath,
+		Interval:    volume.Interval,
+		BucketStart: volume.BucketStart,
+		Role:        role,
+		Address:     address,
+	})
+	if err != nil || !inserted {
+		return 0, err
+	}
+	return 1, nil
+}
+
+func BucketStart(t time.Time, duration time.Duration) time.Time {
+	return t.UTC().Truncate(duration)
+}
+
+// NewTokenVolume 은 이벤트 한 건에 대한 증가분을 만든다. 고유 송신자/수신자 수는 호출자가 채운다.
+func NewTokenVolume(event model.TokenEvent, interval string, bucketStart time.Time) model.TokenVolume {
+	volume := model.TokenVolume{
+		ChainID:     event.ChainID,
+		TokenPath:   event.PkgPath,
+		Interval:    interval,
+		BucketStart: bucketStart,
+	}
+	switch event.Func {
+	case block_synchronizer.EventFuncTransfer:
+		volume.TransferCount = 1
+		volume.TransferVolume = event.Amount
+	case block_synchronizer.EventFuncMint:
+		volume.MintCount = 1
+		volume.MintVolume = event.Amount
+	case block_synchronizer.EventFuncBurn:
+		volume.BurnCount = 1
+		volume.BurnVolume = event.Amount
+	}
+	return volume
+}
@@ -0,0 +1,55 @@
+package consumer
+
+import (
+	"github.com/stretchr/testify/assert"
+	block_synchronizer "onbloc/internal/service/block-synchronizer"
+	"onbloc/pkg/model"
+	"testing"
+	"time"
+)
+
+func TestBucketStart(t *testing.T) {
+	eventTime := time.Date(2025, 6, 20, 13, 45, 10, 0, time.FixedZone("KST", 9*60*60))
+
+	assert.Equal(t, time.Date(2025, 6, 20, 4, 0, 0, 0, time.UTC), BucketStart(eventTime, time.Hour))
+	assert.Equal(t, time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC), BucketStart(eventTime, 24*time.Hour))
+}
+
+func TestNewTokenVolume(t *testing.T) {
+	bucketStart := time.Date(2025, 6, 20, 4, 0, 0, 0, time.UTC)
+	event := model.TokenEvent{
+		ChainID: "dev",
+		PkgPath: "gno.land/r/gnoswap/v1/test_token/foo",
+		From:    "g1from",
+		To:      "g1to",
+		Amount:  100,
+	}
+
+	t.Run("transfer", func(t *testing.T) {
+		event.Func = block_synchronizer.EventFuncTransfer
+		volume := NewTokenVolume(event, model.VolumeIntervalHour, bucketStart)
+		assert.Equal(t, model.TokenVolume{
+			ChainID:        "dev",
+			TokenPath:      event.PkgPath,
+			Interval:       model.VolumeIntervalHour,
+			BucketStart:    bucketStart,
+			TransferCount:  1,
+			TransferVolume: 100,
+		}, volume)
+	})
+
+	t.Run("mint", func(t *testing.T) {
+		event.Func = block_synchronizer.EventFuncMint
+		volume := NewTokenVolume(event, model.VolumeIntervalDay, bucketStart)
+		assert.Equal(t, int64(1), volume.MintCount)
+		assert.Equal(t, int64(100), volume.MintVolume)
+		assert.Equal(t, int64(0), volume.TransferCount)
+	})
+
+	t.Run("burn", func(t *testing.T) {
+		event.Func = block_synchronizer.EventFuncBurn
+		volume := NewTokenVolume(event, model.VolumeIntervalDay, bucketStart)
+		assert.Equal(t, int64(1), volume.BurnCount)
+		assert.Equal(t, int64(100), volume.BurnVolume)
+	})
+}
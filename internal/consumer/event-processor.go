@@ -6,10 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
-	"log"
+	"log/slog"
+	"onbloc/internal/health"
+	"onbloc/internal/logging"
+	"onbloc/internal/metrics"
 	"onbloc/internal/repository/postgresdb"
 	block_synchronizer "onbloc/internal/service/block-synchronizer"
+	"onbloc/internal/tracing"
 	"onbloc/pkg/caching"
 	"onbloc/pkg/messaging"
 	"onbloc/pkg/model"
@@ -17,19 +23,29 @@ import (
 )
 
 type EventProcessor struct {
-	caching         caching.Caching
-	messageQueue    *messaging.SQSClient
-	repository      *postgresdb.Repository
-	eventStrategies map[string]EventStrategy
-	batchSize       int
+	caching          caching.Caching
+	messageQueue     *messaging.SQSClient
+	repository       *postgresdb.Repository
+	volumeAggregator *VolumeAggregator
+	webhookEnqueuer  *WebhookEnqueuer
+	balanceNotifier  *BalanceNotifier
+	cacheInvalidator *CacheInvalidator
+	heartbeat        *health.Heartbeat
+	eventStrategies  map[string]EventStrategy
+	batchSize        int
 }
 
 func NewEventProcessor(cache caching.Caching, messageQueue *messaging.SQSClient, repository *postgresdb.Repository, batchSize int) *EventProcessor {
 	p := &EventProcessor{
-		caching:      cache,
-		messageQueue: messageQueue,
-		repository:   repository,
-		batchSize:    batchSize,
+		caching:          cache,
+		messageQueue:     messageQueue,
+		repository:       repository,
+		volumeAggregator: NewVolumeAggregator(repository),
+		webhookEnqueuer:  NewWebhookEnqueuer(repository),
+		balanceNotifier:  NewBalanceNotifier(repository),
+		cacheInvalidator: NewCacheInvalidator(cache),
+		heartbeat:        health.NewHeartbeat(),
+		batchSize:        batchSize,
 	}
 	p.eventStrategies = map[string]EventStrategy{
 		block_synchronizer.EventFuncTransfer: p.processTransferEvent,
@@ -41,57 +57,127 @@ func NewEventProcessor(cache caching.Caching, messageQueue *messaging.SQSClient,
 
 type EventStrategy func(ctx context.Context, tx *gorm.DB, event model.TokenEvent) error
 
+// Start 는 ctx 가 취소될 때까지 메시지를 받아 처리한다. 취소되면 처리 중인 메시지를 마친 뒤 ctx.Err() 를 반환한다.
 func (p EventProcessor) Start(ctx context.Context) error {
+	go p.reportQueueDepth(ctx)
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			if err := p.consume(ctx); err != nil {
-				log.Printf("consume err: %v", err)
+			if err := p.consume(ctx); err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "failed to consume message", logging.Err(err))
 			}
 		}
 	}
 }
 
+// Heartbeat 는 메시지를 정상적으로 받거나 처리할 때마다 갱신된다. 큐가 비어 있어도 갱신되므로,
+// 오래 갱신되지 않으면 수신/처리가 멈춘 것이다.
+func (p EventProcessor) Heartbeat() *health.Heartbeat {
+	return p.heartbeat
+}
+
+const queueDepthInterval = 15 * time.Second
+
+func (p EventProcessor) reportQueueDepth(ctx context.Context) {
+	ticker := time.NewTicker(queueDepthInterval)
+	defer ticker.Stop()
+	for {
+		metrics.SetQueueDepth(p.messageQueue.GetMessageCount(ctx))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+const emptyQueueBackoff = 3 * time.Second
+
 func (p EventProcessor) consume(ctx context.Context) error {
 	message, err := p.messageQueue.ReceiveMessage(ctx)
 	if err != nil {
 		return err
 	}
 	if message.IsEmpty() {
+		p.heartbeat.Beat()
 		// todo: event-processor의 운영 환경 확인 후, SQS의 waitTimeout 설정 고려.
-		time.Sleep(time.Second * 3)
+		select {
+		case <-ctx.Done():
+		case <-time.After(emptyQueueBackoff):
+		}
 		return nil
 	}
 
+	// 종료 요청 이후에는 ctx 가 취소되어 있으므로, 처리와 반환은 취소되지 않는 ctx 로 끝까지 진행한다.
+	inflight := context.WithoutCancel(ctx)
+	if ctx.Err() != nil {
+		// 종료 중에 받은 메시지는 처리하지 않고, 가시성 제한 시간을 기다리지 않도록 바로 되돌린다.
+		return p.messageQueue.ReleaseMessage(inflight, message)
+	}
+
+	metrics.IncReceivedMessages()
+	inflight = logging.With(inflight, logging.KeyMessageID, message.MessageID)
+	err = p.handleMessage(message.Context(inflight), message)
+	if err != nil && ctx.Err() != nil {
+		// 종료 중에 실패한 메시지는 다른 인스턴스가 곧바로 다시 받을 수 있게 되돌린다.
+		if releaseErr := p.messageQueue.ReleaseMessage(inflight, message); releaseErr != nil {
+			slog.WarnContext(inflight, "failed to release message", logging.Err(releaseErr))
+		}
+	}
+	return err
+}
+
+// handleMessage 는 발행 측(block-synchronizer) 트레이스에 이어지는 consume span 안에서 메시지를 처리한다.
+func (p EventProcessor) handleMessage(ctx context.Context, message messaging.MessageObject) (err error) {
+	ctx, span := tracing.Start(ctx, "sqs.consume", trace.WithSpanKind(trace.SpanKindConsumer))
+	defer func() { tracing.End(span, err) }()
+
 	var tokenEvent model.TokenEvent
 	err = json.Unmarshal([]byte(message.JsonData), &tokenEvent)
 	if err != nil {
 		p.messageQueue.DeleteMessage(ctx, message)
 		return err
 	}
+	ctx = logging.With(ctx,
+		logging.KeyChainID, tokenEvent.ChainID,
+		logging.KeyTxHash, tokenEvent.TransactionHash,
+		logging.KeyEventIndex, tokenEvent.TxEventIndex)
 
-	log.Println(fmt.Sprintf("received token event. hash: %s, idx: %d", tokenEvent.TransactionHash, tokenEvent.TxEventIndex))
+	slog.DebugContext(ctx, "received token event")
 	if err = p.ProcessEvent(ctx, tokenEvent); err != nil {
-		log.Printf("Failed to process event: %v", err)
+		slog.ErrorContext(ctx, "failed to process token event", logging.Err(err))
 		return err
 	}
 
-	log.Println(fmt.Sprintf("processed token event. hash: %s, idx: %d", tokenEvent.TransactionHash, tokenEvent.TxEventIndex))
+	p.heartbeat.Beat()
+	slog.InfoContext(ctx, "processed token event")
 	return p.messageQueue.DeleteMessage(ctx, message)
 }
 
 var duplicationError = "23505"
 
-func (p EventProcessor) ProcessEvent(ctx context.Context, event model.TokenEvent) error {
-	return p.repository.WithTransaction(ctx, func(db *gorm.DB) error {
+// errDuplicateEvent 는 이미 처리한 이벤트의 트랜잭션을 되돌리기 위해 사용하며, ProcessEvent 밖으로 반환되지 않는다.
+var errDuplicateEvent = errors.New("duplicate token event")
+
+func (p EventProcessor) ProcessEvent(ctx context.Context, event model.TokenEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "ProcessEvent", trace.WithAttributes(
+		attribute.String("chain_id", event.ChainID),
+		attribute.String("tx_hash", event.TransactionHash),
+		attribute.Int("tx_event_index", event.TxEventIndex),
+		attribute.String("func", event.Func),
+		attribute.String("token_path", event.PkgPath)))
+	defer func() { tracing.End(span, err) }()
+
+	start := time.Now()
+	err = p.repository.WithTransaction(ctx, func(db *gorm.DB) error {
 		err := p.repository.InsertTokenEventTx(ctx, db, event)
 		if err != nil {
 			var pgErr *pgconn.PgError
 			if errors.As(err, &pgErr) {
 				if pgErr.Code == duplicationError {
-					return nil
+					return errDuplicateEvent
 				}
 				return err
 			}
@@ -102,25 +188,46 @@ func (p EventProcessor) ProcessEvent(ctx context.Context, event model.TokenEvent
 		if !exists {
 			return fmt.Errorf("unsupported event function: %s", event.Func)
 		}
-		return strategy(ctx, db, event)
+		if err = strategy(ctx, db, event); err != nil {
+			return err
+		}
+		if err = p.volumeAggregator.Aggregate(ctx, db, event); err != nil {
+			return err
+		}
+		if err = p.webhookEnqueuer.Enqueue(ctx, db, event); err != nil {
+			return err
+		}
+		return p.balanceNotifier.Notify(ctx, db, event)
 	})
+	if errors.Is(err, errDuplicateEvent) {
+		metrics.ObserveEvent(event.ChainID, metrics.ResultDuplicate, start)
+		span.SetAttributes(attribute.Bool("duplicate", true))
+		return nil
+	}
+	if err != nil {
+		metrics.ObserveEvent(event.ChainID, metrics.ResultFailed, start)
+		return err
+	}
+	metrics.ObserveEvent(event.ChainID, metrics.ResultProcessed, start)
+	p.cacheInvalidator.Invalidate(ctx, event)
+	return nil
 }
 
 func (p EventProcessor) processMintEvent(ctx context.Context, tx *gorm.DB, event model.TokenEvent) error {
-	return p.repository.UpsertBalance(ctx, tx, event.PkgPath, event.To, event.Amount)
+	return p.repository.UpsertBalance(ctx, tx, event.ChainID, event.PkgPath, event.To, event.Amount)
 }
 
 func (p EventProcessor) processBurnEvent(ctx context.Context, tx *gorm.DB, event model.TokenEvent) error {
-	return p.repository.UpsertBalance(ctx, tx, event.PkgPath, event.From, -event.Amount)
+	return p.repository.UpsertBalance(ctx, tx, event.ChainID, event.PkgPath, event.From, -event.Amount)
 }
 
 func (p EventProcessor) processTransferEvent(ctx context.Context, tx *gorm.DB, event model.TokenEvent) error {
-	err := p.repository.UpsertBalance(ctx, tx, event.PkgPath, event.To, event.Amount)
+	err := p.repository.UpsertBalance(ctx, tx, event.ChainID, event.PkgPath, event.To, event.Amount)
 	if err != nil {
 		return err
 	}
 
-	err = p.repository.UpsertBalance(ctx, tx, event.PkgPath, event.From, -event.Amount)
+	err = p.repository.UpsertBalance(ctx, tx, event.ChainID, event.PkgPath, event.From, -event.Amount)
 	if err != nil {
 		return err
 	}
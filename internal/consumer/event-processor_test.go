@@ -27,9 +27,11 @@ func TestEventProcessor_ProcessEvent(t *testing.T) {
 
 	ep := NewEventProcessor(redis, messageQueue, repository, 0)
 
+	chainID := "dev"
 	transactionHash := "Madp4C64dGZV4zrrNrz1HduBNa7yDBZRr544oNv39e4"
 	t.Run("mint 이벤트 처리", func(t *testing.T) {
 		te := model.TokenEvent{
+			ChainID:         chainID,
 			Type:            block_synchronizer.EventTypeTransfer,
 			TransactionHash: transactionHash,
 			TxEventIndex:    1,
@@ -44,6 +46,7 @@ func TestEventProcessor_ProcessEvent(t *testing.T) {
 
 	t.Run("burn 이벤트 처리", func(t *testing.T) {
 		te := model.TokenEvent{
+			ChainID:         chainID,
 			Type:            block_synchronizer.EventTypeTransfer,
 			TransactionHash: transactionHash,
 			TxEventIndex:    2,
@@ -59,6 +62,7 @@ func TestEventProcessor_ProcessEvent(t *testing.T) {
 
 	t.Run("Transfer 이벤트 처리", func(t *testing.T) {
 		te := model.TokenEvent{
+			ChainID:         chainID,
 			Type:            block_synchronizer.EventTypeTransfer,
 			TransactionHash: transactionHash,
 			TxEventIndex:    3,
@@ -73,6 +77,6 @@ func TestEventProcessor_ProcessEvent(t *testing.T) {
 	})
 
 	defer func() {
-		db.Exec("delete from token_events where transaction_hash = ?", transactionHash)
+		db.Exec("delete from token_events where chain_id = ? and transaction_hash = ?", chainID, transactionHash)
 	}()
 }
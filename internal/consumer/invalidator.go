@@ -0,0 +1,38 @@
+package consumer
+
+import (
+	"context"
+	"log/slog"
+	"onbloc/internal/logging"
+	"onbloc/pkg/caching"
+	"onbloc/pkg/model"
+)
+
+// CacheInvalidator 는 잔액이 바뀐 주소와 토큰의 버전 키를 올려 balance-api 의 캐시 항목을 무효화한다.
+// 트랜잭션 커밋 이후에 호출되어야 이전 잔액이 새 버전으로 캐싱되지 않는다.
+type CacheInvalidator struct {
+	caching caching.Caching
+}
+
+func NewCacheInvalidator(cache caching.Caching) *CacheInvalidator {
+	return &CacheInvalidator{caching: cache}
+}
+
+func (i CacheInvalidator) Invalidate(ctx context.Context, event model.TokenEvent) {
+	if i.caching == nil {
+		return
+	}
+
+	keys := []string{caching.TokenVersionKey(event.ChainID, event.PkgPath)}
+	for _, address := range []string{event.From, event.To} {
+		if address != "" {
+			keys = append(keys, caching.AddressVersionKey(event.ChainID, address))
+		}
+	}
+	for _, key := range keys {
+		// 무효화 실패는 이벤트 처리를 되돌리지 않는다. 캐시 항목은 TTL 이 지나면 만료된다.
+		if err := i.caching.IncrBy(ctx, key, 1); err != nil {
+			slog.WarnContext(ctx, "failed to invalidate cache", "key", key, logging.Err(err))
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package consumer
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"onbloc/internal/repository/postgresdb"
+	"onbloc/pkg/model"
+)
+
+// BalanceNotifier 는 이벤트와 변경된 잔액을 NOTIFY 로 발행한다. balance-api 의 스트림이 이를 LISTEN 한다.
+type BalanceNotifier struct {
+	repository *postgresdb.Repository
+}
+
+func NewBalanceNotifier(repository *postgresdb.Repository) *BalanceNotifier {
+	return &BalanceNotifier{repository: repository}
+}
+
+func (n BalanceNotifier) Notify(ctx context.Context, tx *gorm.DB, event model.TokenEvent) error {
+	var addresses []string
+	for _, address := range []string{event.From, event.To} {
+		if address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	balances, err := n.repository.GetBalancesTx(ctx, tx, event.ChainID, event.PkgPath, addresses)
+	if err != nil {
+		return err
+	}
+
+	return n.repository.Notify(ctx, tx, model.TokenEventChannel, model.TokenEventNotification{
+		Event:    event,
+		Balances: balances,
+	})
+}
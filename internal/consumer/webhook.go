@@ -0,0 +1,47 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"gorm.io/gorm"
+	"onbloc/internal/repository/postgresdb"
+	"onbloc/pkg/model"
+	"time"
+)
+
+// WebhookEnqueuer 는 이벤트와 일치하는 구독마다 전달 건(webhook_deliveries)을 이벤트 처리 트랜잭션 안에서 적재한다.
+// 실제 전송은 webhook-dispatcher 가 담당한다.
+type WebhookEnqueuer struct {
+	repository *postgresdb.Repository
+}
+
+func NewWebhookEnqueuer(repository *postgresdb.Repository) *WebhookEnqueuer {
+	return &WebhookEnqueuer{repository: repository}
+}
+
+func (w WebhookEnqueuer) Enqueue(ctx context.Context, tx *gorm.DB, event model.TokenEvent) error {
+	subscriptions, err := w.repository.FindMatchingWebhookSubscriptions(ctx, tx, event)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]model.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, model.WebhookDelivery{
+			SubscriptionID:  subscription.ID,
+			ChainID:         event.ChainID,
+			TransactionHash: event.TransactionHash,
+			TxEventIndex:    event.TxEventIndex,
+			Payload:         payload,
+			Status:          model.WebhookDeliveryPending,
+			NextAttemptAt:   now,
+		})
+	}
+	return w.repository.InsertWebhookDeliveries(ctx, tx, deliveries)
+}
@@ -0,0 +1,477 @@
+package graphql_api
+
+import (
+	"context"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"onbloc/internal/response"
+	balance_api_service "onbloc/internal/service/balance-api-service"
+	"onbloc/internal/validation"
+	"onbloc/pkg/model"
+	"strconv"
+)
+
+type chainIDKey struct{}
+
+func WithChainID(ctx context.Context, chainID string) context.Context {
+	return context.WithValue(ctx, chainIDKey{}, chainID)
+}
+
+func chainID(p graphql.ResolveParams) string {
+	chainID, _ := p.Context.Value(chainIDKey{}).(string)
+	return chainID
+}
+
+// Long 은 int32 범위를 넘는 잔액, 높이, 가스 값을 JSON 숫자로 표현한다.
+var Long = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Long",
+	Description: "64-bit integer",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case int64:
+			return v
+		case int:
+			return int64(v)
+		case *int64:
+			if v == nil {
+				return nil
+			}
+			return *v
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case int:
+			return int64(v)
+		case int64:
+			return v
+		case float64:
+			return int64(v)
+		case string:
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil
+			}
+			return parsed
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch v := valueAST.(type) {
+		case *ast.IntValue:
+			parsed, err := strconv.ParseInt(v.Value, 10, 64)
+			if err != nil {
+				return nil
+			}
+			return parsed
+		case *ast.StringValue:
+			parsed, err := strconv.ParseInt(v.Value, 10, 64)
+			if err != nil {
+				return nil
+			}
+			return parsed
+		}
+		return nil
+	},
+})
+
+var connectionArgs = graphql.FieldConfigArgument{
+	"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+	"after": &graphql.ArgumentConfig{Type: graphql.String},
+}
+
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"endCursor":   &graphql.Field{Type: graphql.String},
+	},
+})
+
+func connectionType(name string, node *graphql.Object) *graphql.Object {
+	edge := graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Edge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(node)},
+		},
+	})
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Connection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edge)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+}
+
+func stringArg(p graphql.ResolveParams, name string) string {
+	value, _ := p.Args[name].(string)
+	return value
+}
+
+func intArg(p graphql.ResolveParams, name string) int {
+	value, _ := p.Args[name].(int)
+	return value
+}
+
+// normalizeArgs 는 address, tokenPath, path 인자가 있으면 REST 와 같은 규칙으로 검증하고 정규화한 값으로 바꾼다.
+func normalizeArgs(p graphql.ResolveParams) error {
+	v := validation.New()
+	if address := stringArg(p, "address"); address != "" {
+		p.Args["address"] = v.Address("address", address)
+	}
+	for _, name := range []string{"tokenPath", "path"} {
+		if path := stringArg(p, name); path != "" {
+			p.Args[name] = v.RealmPath(name, path)
+		}
+	}
+	return v.Err()
+}
+
+// nullIfNotFound 는 단건 조회에서 ErrNotFound 를 null 로 반환한다.
+func nullIfNotFound(value interface{}, err error) (interface{}, error) {
+	if errors.Is(err, balance_api_service.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// NewSchema 는 Balance, Token, TokenEvent, Block, Transaction 과 그 관계(account → balances → token)를 노출하는 스키마를 만든다.
+func NewSchema(service *balance_api_service.Service) (graphql.Schema, error) {
+	var (
+		tokenType       *graphql.Object
+		balanceType     *graphql.Object
+		tokenEventType  *graphql.Object
+		blockType       *graphql.Object
+		transactionType *graphql.Object
+	)
+
+	resolveToken := func(p graphql.ResolveParams, tokenPath string) (interface{}, error) {
+		return nullIfNotFound(service.GetToken(p.Context, chainID(p), tokenPath))
+	}
+	resolveBlock := func(p graphql.ResolveParams, height int64) (interface{}, error) {
+		return nullIfNotFound(service.GetBlock(p.Context, chainID(p), height))
+	}
+	resolveTransaction := func(p graphql.ResolveParams, hash string) (interface{}, error) {
+		return nullIfNotFound(service.GetTransaction(p.Context, chainID(p), hash))
+	}
+
+	tokenType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Token",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"path":    &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: func(p graphql.ResolveParams) (interface{}, error) { return p.Source.(response.Token).TokenPath, nil }},
+				"symbol":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"holders": &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"balances": &graphql.Field{
+					Type: graphql.NewNonNull(connectionType("Balance", balanceType)),
+					Args: connectionArgs,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						token := p.Source.(response.Token)
+						return service.GetBalanceConnection(p.Context, chainID(p), "", token.TokenPath, intArg(p, "first"), stringArg(p, "after"))
+					},
+				},
+			}
+		}),
+	})
+
+	balanceType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Balance",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"address":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"tokenPath": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"amount":    &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"token": &graphql.Field{
+					Type: tokenType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return resolveToken(p, p.Source.(response.AccountBalance).TokenPath)
+					},
+				},
+			}
+		}),
+	})
+
+	tokenEventType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "TokenEvent",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"transactionHash": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"txEventIndex":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"func":            &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"tokenPath":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"fromAddress":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"toAddress":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"amount":          &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"token": &graphql.Field{
+					Type: tokenType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return resolveToken(p, p.Source.(response.TokenEvent).TokenPath)
+					},
+				},
+				"transaction": &graphql.Field{
+					Type: transactionType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return resolveTransaction(p, p.Source.(response.TokenEvent).TransactionHash)
+					},
+				},
+			}
+		}),
+	})
+
+	blockType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Block",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"hash":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"height":   &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"time":     &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"numTxs":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"totalTxs": &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"transactions": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transactionType))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						resp, err := service.GetBlockTransactions(p.Context, chainID(p), p.Source.(response.Block).Height)
+						if err != nil {
+							return nil, err
+						}
+						return resp.Transactions, nil
+					},
+				},
+			}
+		}),
+	})
+
+	messageType := newMessageType()
+	gasFeeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "GasFee",
+		Fields: graphql.Fields{
+			"amount": &graphql.Field{Type: graphql.NewNonNull(Long)},
+			"denom":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	transactionType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transaction",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"hash":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"blockHeight": &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"index":       &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"success":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+				"gasWanted":   &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"gasUsed":     &graphql.Field{Type: graphql.NewNonNull(Long)},
+				"gasFee":      &graphql.Field{Type: graphql.NewNonNull(gasFeeType)},
+				"memo":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"messages":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(messageType)))},
+				"tokenEvents": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tokenEventType)))},
+				"block": &graphql.Field{
+					Type: blockType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return resolveBlock(p, p.Source.(response.Transaction).BlockHeight)
+					},
+				},
+			}
+		}),
+	})
+
+	accountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Account",
+		Fields: graphql.Fields{
+			"address": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(string), nil
+				},
+			},
+			"balances": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType("AccountBalance", balanceType)),
+				Args: graphql.FieldConfigArgument{
+					"tokenPath": &graphql.ArgumentConfig{Type: graphql.String},
+					"first":     connectionArgs["first"],
+					"after":     connectionArgs["after"],
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := normalizeArgs(p); err != nil {
+						return nil, err
+					}
+					return service.GetBalanceConnection(p.Context, chainID(p), p.Source.(string), stringArg(p, "tokenPath"), intArg(p, "first"), stringArg(p, "after"))
+				},
+			},
+			"transactions": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType("Transaction", transactionType)),
+				Args: connectionArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return service.GetAccountTransactionConnection(p.Context, chainID(p), p.Source.(string), intArg(p, "first"), stringArg(p, "after"))
+				},
+			},
+			"tokenEvents": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType("AccountTokenEvent", tokenEventType)),
+				Args: graphql.FieldConfigArgument{
+					"tokenPath": &graphql.ArgumentConfig{Type: graphql.String},
+					"func":      &graphql.ArgumentConfig{Type: graphql.String},
+					"first":     connectionArgs["first"],
+					"after":     connectionArgs["after"],
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := normalizeArgs(p); err != nil {
+						return nil, err
+					}
+					filter := model.TokenEventFilter{Address: p.Source.(string), TokenPath: stringArg(p, "tokenPath"), Func: stringArg(p, "func")}
+					return service.GetTokenEventConnection(p.Context, chainID(p), filter, intArg(p, "first"), stringArg(p, "after"))
+				},
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"account": &graphql.Field{
+				Type: accountType,
+				Args: graphql.FieldConfigArgument{"address": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := normalizeArgs(p); err != nil {
+						return nil, err
+					}
+					return stringArg(p, "address"), nil
+				},
+			},
+			"token": &graphql.Field{
+				Type: tokenType,
+				Args: graphql.FieldConfigArgument{"path": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := normalizeArgs(p); err != nil {
+						return nil, err
+					}
+					return resolveToken(p, stringArg(p, "path"))
+				},
+			},
+			"tokens": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType("Token", tokenType)),
+				Args: graphql.FieldConfigArgument{
+					"search": &graphql.ArgumentConfig{Type: graphql.String},
+					"first":  connectionArgs["first"],
+					"after":  connectionArgs["after"],
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return service.GetTokenConnection(p.Context, chainID(p), stringArg(p, "search"), intArg(p, "first"), stringArg(p, "after"))
+				},
+			},
+			"balances": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType("BalanceList", balanceType)),
+				Args: graphql.FieldConfigArgument{
+					"address":   &graphql.ArgumentConfig{Type: graphql.String},
+					"tokenPath": &graphql.ArgumentConfig{Type: graphql.String},
+					"first":     connectionArgs["first"],
+					"after":     connectionArgs["after"],
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := normalizeArgs(p); err != nil {
+						return nil, err
+					}
+					return service.GetBalanceConnection(p.Context, chainID(p), stringArg(p, "address"), stringArg(p, "tokenPath"), intArg(p, "first"), stringArg(p, "after"))
+				},
+			},
+			"tokenEvents": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType("TokenEvent", tokenEventType)),
+				Args: graphql.FieldConfigArgument{
+					"address":   &graphql.ArgumentConfig{Type: graphql.String},
+					"tokenPath": &graphql.ArgumentConfig{Type: graphql.String},
+					"func":      &graphql.ArgumentConfig{Type: graphql.String},
+					"first":     connectionArgs["first"],
+					"after":     connectionArgs["after"],
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := normalizeArgs(p); err != nil {
+						return nil, err
+					}
+					filter := model.TokenEventFilter{Address: stringArg(p, "address"), TokenPath: stringArg(p, "tokenPath"), Func: stringArg(p, "func")}
+					return service.GetTokenEventConnection(p.Context, chainID(p), filter, intArg(p, "first"), stringArg(p, "after"))
+				},
+			},
+			"block": &graphql.Field{
+				Type: blockType,
+				Args: graphql.FieldConfigArgument{"height": &graphql.ArgumentConfig{Type: graphql.NewNonNull(Long)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					height, _ := p.Args["height"].(int64)
+					return resolveBlock(p, height)
+				},
+			},
+			"blocks": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType("Block", blockType)),
+				Args: connectionArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return service.GetBlockConnection(p.Context, chainID(p), intArg(p, "first"), stringArg(p, "after"))
+				},
+			},
+			"transaction": &graphql.Field{
+				Type: transactionType,
+				Args: graphql.FieldConfigArgument{"hash": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolveTransaction(p, stringArg(p, "hash"))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func newMessageType() *graphql.Object {
+	packageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Package",
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"path":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"files": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))},
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Message",
+		Fields: graphql.Fields{
+			"route":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"typeUrl": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"bankMsgSend": &graphql.Field{Type: graphql.NewObject(graphql.ObjectConfig{
+				Name: "BankMsgSend",
+				Fields: graphql.Fields{
+					"fromAddress": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"toAddress":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"amount":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				},
+			})},
+			"msgCall": &graphql.Field{Type: graphql.NewObject(graphql.ObjectConfig{
+				Name: "MsgCall",
+				Fields: graphql.Fields{
+					"caller":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"send":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"pkgPath": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"func":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"args":    &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+				},
+			})},
+			"msgAddPackage": &graphql.Field{Type: graphql.NewObject(graphql.ObjectConfig{
+				Name: "MsgAddPackage",
+				Fields: graphql.Fields{
+					"creator": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"deposit": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"package": &graphql.Field{Type: graphql.NewNonNull(packageType)},
+				},
+			})},
+			"msgRun": &graphql.Field{Type: graphql.NewObject(graphql.ObjectConfig{
+				Name: "MsgRun",
+				Fields: graphql.Fields{
+					"caller":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"send":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+					"package": &graphql.Field{Type: graphql.NewNonNull(packageType)},
+				},
+			})},
+		},
+	})
+}
@@ -0,0 +1,66 @@
+package graphql_api
+
+import (
+	"context"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewSchema(t *testing.T) {
+	schema, err := NewSchema(nil)
+	assert.NoError(t, err)
+
+	t.Run("account 는 주소를 그대로 반환한다", func(t *testing.T) {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ account(address: "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5") { address } }`,
+			Context:       WithChainID(context.TODO(), "test5"),
+		})
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, map[string]interface{}{
+			"account": map[string]interface{}{"address": "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"},
+		}, result.Data)
+	})
+
+	t.Run("account 는 주소를 소문자로 정규화한다", func(t *testing.T) {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ account(address: "G1JG8MTUTU9KHHFWC4NXMUHCPFTF0PAJDHFVSQF5") { address } }`,
+			Context:       context.TODO(),
+		})
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, map[string]interface{}{
+			"account": map[string]interface{}{"address": "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"},
+		}, result.Data)
+	})
+
+	t.Run("잘못된 주소는 오류를 반환한다", func(t *testing.T) {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ account(address: "g1abc") { address } }`,
+			Context:       context.TODO(),
+		})
+		assert.NotEmpty(t, result.Errors)
+	})
+
+	t.Run("존재하지 않는 필드는 검증 오류를 반환한다", func(t *testing.T) {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ account(address: "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5") { unknown } }`,
+			Context:       context.TODO(),
+		})
+		assert.NotEmpty(t, result.Errors)
+	})
+}
+
+func TestLong(t *testing.T) {
+	t.Run("int32 범위를 넘는 값을 직렬화한다", func(t *testing.T) {
+		assert.Equal(t, int64(1<<40), Long.Serialize(int64(1<<40)))
+	})
+
+	t.Run("문자열 값을 파싱한다", func(t *testing.T) {
+		assert.Equal(t, int64(1<<40), Long.ParseValue("1099511627776"))
+		assert.Nil(t, Long.ParseValue("abc"))
+	})
+}
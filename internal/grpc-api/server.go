@@ -0,0 +1,203 @@
+package grpc_api
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"onbloc/internal/logging"
+	"onbloc/internal/response"
+	balance_api_service "onbloc/internal/service/balance-api-service"
+	"onbloc/internal/stream"
+	"onbloc/internal/validation"
+	"onbloc/pkg/model"
+	balancev1 "onbloc/pkg/pb/balance/v1"
+)
+
+// Server 는 REST 핸들러와 같은 balance_api_service.Service 를 사용해 BalanceService 를 제공한다.
+type Server struct {
+	balancev1.UnimplementedBalanceServiceServer
+	service        *balance_api_service.Service
+	hub            *stream.Hub
+	defaultChainID string
+}
+
+func NewServer(service *balance_api_service.Service, hub *stream.Hub, defaultChainID string) *Server {
+	return &Server{
+		service:        service,
+		hub:            hub,
+		defaultChainID: defaultChainID,
+	}
+}
+
+func (s Server) chainID(chainID string) string {
+	if chainID != "" {
+		return chainID
+	}
+	return s.defaultChainID
+}
+
+func (s Server) GetBalances(ctx context.Context, req *balancev1.GetBalancesRequest) (*balancev1.GetBalancesResponse, error) {
+	v := validation.New()
+	address := v.Address("address", req.GetAddress())
+	if err := v.Err(); err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	resp, err := s.service.GetTokenBalances(ctx, s.chainID(req.GetChainId()), address)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	balances := make([]*balancev1.TokenBalance, 0, len(resp.Balances))
+	for _, balance := range resp.Balances {
+		balances = append(balances, &balancev1.TokenBalance{
+			TokenPath: balance.TokenPath,
+			Amount:    balance.Amount,
+		})
+	}
+	return &balancev1.GetBalancesResponse{Balances: balances}, nil
+}
+
+func (s Server) GetTokenBalance(ctx context.Context, req *balancev1.GetTokenBalanceRequest) (*balancev1.AccountBalance, error) {
+	v := validation.New()
+	tokenPath := v.RealmPath("token_path", req.GetTokenPath())
+	address := v.Address("address", req.GetAddress())
+	if err := v.Err(); err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	resp, err := s.service.GetTokenPathBalanceByAddress(ctx, s.chainID(req.GetChainId()), tokenPath, address)
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+	if len(resp.AccountBalances) == 0 {
+		return nil, status.Errorf(codes.NotFound, "balance of %s for %s not found", address, tokenPath)
+	}
+	return toAccountBalance(resp.AccountBalances[0]), nil
+}
+
+func (s Server) ListHolders(ctx context.Context, req *balancev1.ListHoldersRequest) (*balancev1.ListHoldersResponse, error) {
+	v := validation.New()
+	tokenPath := v.RealmPath("token_path", req.GetTokenPath())
+	if err := v.Err(); err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	holders, err := s.service.GetTokenHolders(ctx, s.chainID(req.GetChainId()), tokenPath, req.GetAfter(), int(req.GetFirst()))
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	resp := &balancev1.ListHoldersResponse{
+		Holders:    make([]*balancev1.AccountBalance, 0, len(holders.Holders)),
+		NextCursor: holders.NextCursor,
+	}
+	for _, holder := range holders.Holders {
+		resp.Holders = append(resp.Holders, toAccountBalance(holder))
+	}
+	return resp, nil
+}
+
+func (s Server) ListTransfers(ctx context.Context, req *balancev1.ListTransfersRequest) (*balancev1.ListTransfersResponse, error) {
+	v := validation.New()
+	filter := model.TokenEventFilter{
+		Address:   v.OptionalAddress("address", req.GetAddress()),
+		TokenPath: v.OptionalRealmPath("token_path", req.GetTokenPath()),
+		Func:      req.GetFunc(),
+	}
+	if err := v.Err(); err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+	connection, err := s.service.GetTokenEventConnection(ctx, s.chainID(req.GetChainId()), filter, int(req.GetFirst()), req.GetAfter())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	resp := &balancev1.ListTransfersResponse{Transfers: make([]*balancev1.Transfer, 0, len(connection.Edges))}
+	for _, edge := range connection.Edges {
+		event := edge.Node
+		resp.Transfers = append(resp.Transfers, &balancev1.Transfer{
+			TransactionHash: event.TransactionHash,
+			TxEventIndex:    int32(event.TxEventIndex),
+			Func:            event.Func,
+			FromAddress:     event.FromAddress,
+			ToAddress:       event.ToAddress,
+			TokenPath:       event.TokenPath,
+			Amount:          event.Amount,
+		})
+	}
+	if connection.PageInfo.HasNextPage {
+		resp.NextCursor = connection.PageInfo.EndCursor
+	}
+	return resp, nil
+}
+
+// StreamTransfers 는 WebSocket/SSE 와 같은 Hub 를 구독하며, 잔액 메시지는 보내지 않는다.
+func (s Server) StreamTransfers(req *balancev1.StreamTransfersRequest, srv balancev1.BalanceService_StreamTransfersServer) error {
+	if len(req.GetAddresses()) == 0 && len(req.GetTokenPaths()) == 0 {
+		return status.Error(codes.InvalidArgument, "addresses or token_paths is required")
+	}
+	v := validation.New()
+	addresses := v.Addresses("addresses", req.GetAddresses())
+	tokenPaths := v.RealmPaths("token_paths", req.GetTokenPaths())
+	if err := v.Err(); err != nil {
+		return toStatusError(srv.Context(), err)
+	}
+
+	subscriber := s.hub.Subscribe(s.chainID(req.GetChainId()), addresses, tokenPaths)
+	defer s.hub.Unsubscribe(subscriber)
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case message, ok := <-subscriber.Messages():
+			if !ok {
+				return nil
+			}
+			if message.Type != response.StreamMessageTransfer {
+				continue
+			}
+			err := srv.Send(&balancev1.Transfer{
+				TransactionHash: message.Transfer.TransactionHash,
+				TxEventIndex:    int32(message.Transfer.TxEventIndex),
+				Func:            message.Transfer.Func,
+				FromAddress:     message.Transfer.FromAddress,
+				ToAddress:       message.Transfer.ToAddress,
+				TokenPath:       message.Transfer.TokenPath,
+				Amount:          message.Transfer.Amount,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toAccountBalance(balance response.AccountBalance) *balancev1.AccountBalance {
+	return &balancev1.AccountBalance{
+		Address:   balance.Address,
+		TokenPath: balance.TokenPath,
+		Amount:    balance.Amount,
+	}
+}
+
+// toStatusError 는 REST 의 writeError 처럼 내부 오류와 의존성 장애의 상세 내용은 로그로만 남기고 고정된 메시지를 반환한다.
+func toStatusError(ctx context.Context, err error) error {
+	if errors.Is(err, balance_api_service.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, balance_api_service.ErrInvalidArgument) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	method, _ := grpc.Method(ctx)
+	if errors.Is(err, balance_api_service.ErrUnavailable) {
+		slog.WarnContext(ctx, "dependency unavailable", "method", method, logging.Err(err))
+		return status.Error(codes.Unavailable, "service temporarily unavailable")
+	}
+	slog.ErrorContext(ctx, "request failed", "method", method, logging.Err(err))
+	return status.Error(codes.Internal, "internal server error")
+}
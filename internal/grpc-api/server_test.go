@@ -0,0 +1,60 @@
+package grpc_api
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	balance_api_service "onbloc/internal/service/balance-api-service"
+	balancev1 "onbloc/pkg/pb/balance/v1"
+	"testing"
+)
+
+func TestToStatusError(t *testing.T) {
+	t.Run("ErrNotFound 는 NotFound 로 변환한다", func(t *testing.T) {
+		err := toStatusError(context.TODO(), fmt.Errorf("block 1: %w", balance_api_service.ErrNotFound))
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("ErrInvalidArgument 는 InvalidArgument 로 변환한다", func(t *testing.T) {
+		err := toStatusError(context.TODO(), fmt.Errorf("invalid cursor: %w", balance_api_service.ErrInvalidArgument))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ErrUnavailable 는 Unavailable 로 변환한다", func(t *testing.T) {
+		err := toStatusError(context.TODO(), fmt.Errorf("query: %w", balance_api_service.ErrUnavailable))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, "service temporarily unavailable", status.Convert(err).Message())
+	})
+
+	t.Run("그 외 오류는 Internal 로 변환하고 상세 내용을 노출하지 않는다", func(t *testing.T) {
+		err := toStatusError(context.TODO(), fmt.Errorf("query: dial tcp 10.0.0.5:5432: connection refused"))
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "internal server error", status.Convert(err).Message())
+	})
+}
+
+func TestServer_RequiredArguments(t *testing.T) {
+	server := NewServer(nil, nil, "dev")
+
+	t.Run("GetBalances 는 address 가 필요하다", func(t *testing.T) {
+		_, err := server.GetBalances(context.TODO(), &balancev1.GetBalancesRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("GetTokenBalance 는 token_path 와 address 가 필요하다", func(t *testing.T) {
+		_, err := server.GetTokenBalance(context.TODO(), &balancev1.GetTokenBalanceRequest{Address: "g1abc"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ListHolders 는 token_path 가 필요하다", func(t *testing.T) {
+		_, err := server.ListHolders(context.TODO(), &balancev1.ListHoldersRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("chain_id 가 비어 있으면 기본 체인을 사용한다", func(t *testing.T) {
+		assert.Equal(t, "dev", server.chainID(""))
+		assert.Equal(t, "test5", server.chainID("test5"))
+	})
+}
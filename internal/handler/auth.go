@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"onbloc/internal/apperror"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// RequireAPIKey 는 X-API-Key 헤더가 keys 중 하나와 일치하는 요청만 통과시킨다. keys 가 비어 있으면 모든 요청을 거부한다.
+func RequireAPIKey(keys []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		for _, candidate := range keys {
+			if key != "" && subtle.ConstantTimeCompare([]byte(key), []byte(candidate)) == 1 {
+				c.Next()
+				return
+			}
+		}
+		writeError(c, fmt.Errorf("missing or invalid %s header: %w", APIKeyHeader, apperror.ErrUnauthenticated))
+	}
+}
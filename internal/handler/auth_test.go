@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"onbloc/internal/response"
+	"testing"
+)
+
+func TestRequireAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	serve := func(keys []string, key string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.GET("/webhooks", RequireAPIKey(keys), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+		if key != "" {
+			req.Header.Set(APIKeyHeader, key)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("등록된 키면 통과한다", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve([]string{"old-key", "new-key"}, "new-key").Code)
+	})
+
+	for name, key := range map[string]string{"키가 없으면": "", "다른 키면": "wrong-key"} {
+		t.Run(name+" 401 을 반환한다", func(t *testing.T) {
+			w := serve([]string{"new-key"}, key)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+			var body response.ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "UNAUTHENTICATED", body.Error.Code)
+		})
+	}
+
+	t.Run("키가 설정되지 않으면 모든 요청을 거부한다", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve(nil, "any").Code)
+	})
+}
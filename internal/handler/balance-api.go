@@ -5,25 +5,40 @@ import (
 	"net/http"
 	"onbloc/internal/response"
 	balance_api_service "onbloc/internal/service/balance-api-service"
+	"onbloc/internal/validation"
 	"strconv"
-	"strings"
 )
 
 type BalanceAPIHandler struct {
-	service *balance_api_service.Service
+	service        *balance_api_service.Service
+	defaultChainID string
 }
 
-func NewBalanceAPIHandler(service *balance_api_service.Service) *BalanceAPIHandler {
+func NewBalanceAPIHandler(service *balance_api_service.Service, defaultChainID string) *BalanceAPIHandler {
 	return &BalanceAPIHandler{
-		service: service,
+		service:        service,
+		defaultChainID: defaultChainID,
 	}
 }
 
-func (b *BalanceAPIHandler) GetTokenBalances(c *gin.Context) {
-	wildcard := c.Param("wildcard")
-	wildcard = strings.TrimPrefix(wildcard, "/")
+// chainID 는 /chains/:chainId 하위 라우트에서는 경로 값을, 기존 /tokens 라우트에서는 기본 체인을 사용한다.
+func (b BalanceAPIHandler) chainID(c *gin.Context) string {
+	return chainIDOrDefault(c, b.defaultChainID)
+}
+
+func chainIDOrDefault(c *gin.Context, defaultChainID string) string {
+	if chainID := c.Param("chainId"); chainID != "" {
+		return chainID
+	}
+	return defaultChainID
+}
 
-	address := c.Query("address")
+func (b *BalanceAPIHandler) GetTokenBalances(c *gin.Context) {
+	v := validation.New()
+	address := v.OptionalAddress("address", c.Query("address"))
+	if writeValidation(c, v) {
+		return
+	}
 	limit, err := strconv.Atoi(c.Query("limit"))
 	if err != nil {
 		limit = 20
@@ -34,57 +49,107 @@ func (b *BalanceAPIHandler) GetTokenBalances(c *gin.Context) {
 	}
 
 	if address == "" {
-		resp, err := b.service.GetAllTokenBalances(c, offset, limit)
+		resp, err := b.service.GetAllTokenBalances(c, b.chainID(c), offset, limit)
 		if err != nil {
-			c.JSON(http.StatusNotFound, err)
+			writeError(c, err)
+			return
 		}
 		c.JSON(http.StatusOK, resp)
 	} else {
-		resp, err := b.service.GetTokenBalances(c, address)
+		resp, err := b.service.GetTokenBalances(c, b.chainID(c), address)
 		if err != nil {
-			c.JSON(http.StatusNotFound, err)
+			writeError(c, err)
+			return
 		}
 		c.JSON(http.StatusOK, resp)
 	}
 }
 
 func (b BalanceAPIHandler) GetTokenPathBalances(c *gin.Context) {
-	wildCard := c.Param("wildcard")
-	tokenPath := strings.TrimSuffix(wildCard, "/balances")[1:]
-	address := c.Query("address")
+	v := validation.New()
+	tokenPath := v.RealmPath("tokenPath", c.Param("tokenPath"))
+	address := v.OptionalAddress("address", c.Query("address"))
+	if writeValidation(c, v) {
+		return
+	}
 
 	var resp response.AccountBalancesResponse
 	var err error
 	if address != "" {
-		resp, err = b.service.GetTokenPathBalanceByAddress(c, tokenPath, address)
+		resp, err = b.service.GetTokenPathBalanceByAddress(c, b.chainID(c), tokenPath, address)
 		if err != nil {
-			c.JSON(http.StatusNotFound, err)
+			writeError(c, err)
+			return
 		}
 		c.JSON(http.StatusOK, resp)
 	} else {
-		resp, err = b.service.GetAllTokenPathBalances(c, tokenPath)
+		resp, err = b.service.GetAllTokenPathBalances(c, b.chainID(c), tokenPath)
 		if err != nil {
-			c.JSON(http.StatusNotFound, err)
+			writeError(c, err)
+			return
 		}
 		c.JSON(http.StatusOK, resp)
 	}
 }
 
 func (b BalanceAPIHandler) GetTokenTransferHistory(c *gin.Context) {
-	address := c.Query("address")
+	v := validation.New()
+	address := v.OptionalAddress("address", c.Query("address"))
+	if writeValidation(c, v) {
+		return
+	}
 	var resp response.TransfersResponse
 	var err error
 	if address != "" {
-		resp, err = b.service.GetTokenTransferHistoryByAddress(c, address)
+		resp, err = b.service.GetTokenTransferHistoryByAddress(c, b.chainID(c), address)
 		if err != nil {
-			c.JSON(http.StatusNotFound, err)
+			writeError(c, err)
+			return
 		}
 		c.JSON(http.StatusOK, resp)
 	} else {
-		resp, err = b.service.GetAllTokenTransferHistory(c)
+		resp, err = b.service.GetAllTokenTransferHistory(c, b.chainID(c))
 		if err != nil {
-			c.JSON(http.StatusNotFound, err)
+			writeError(c, err)
+			return
 		}
 		c.JSON(http.StatusOK, resp)
 	}
 }
+
+func (b BalanceAPIHandler) GetToken(c *gin.Context) {
+	v := validation.New()
+	tokenPath := v.RealmPath("tokenPath", c.Param("tokenPath"))
+	if writeValidation(c, v) {
+		return
+	}
+
+	resp, err := b.service.GetToken(c, b.chainID(c), tokenPath)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// TokenExists 는 토큰 라우터가 action 이름(/holders 등)으로 끝나는 토큰 경로를 구분할 때 사용한다.
+func (b BalanceAPIHandler) TokenExists(c *gin.Context, tokenPath string) (bool, error) {
+	return b.service.TokenExists(c, b.chainID(c), tokenPath)
+}
+
+// GetTokenHolders 는 잔액이 있는 보유자를 cursor 기반으로 반환한다.
+func (b BalanceAPIHandler) GetTokenHolders(c *gin.Context) {
+	v := validation.New()
+	tokenPath := v.RealmPath("tokenPath", c.Param("tokenPath"))
+	if writeValidation(c, v) {
+		return
+	}
+	_, limit := pagination(c)
+
+	resp, err := b.service.GetTokenHolders(c, b.chainID(c), tokenPath, c.Query("cursor"), limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"onbloc/internal/request"
+	"onbloc/internal/validation"
+)
+
+// QueryBalances 는 여러 주소와 토큰의 잔액을 한 번에 조회한다.
+func (b BalanceAPIHandler) QueryBalances(c *gin.Context) {
+	var req request.BalanceQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+	v := validation.New()
+	req.Addresses = v.Addresses("addresses", req.Addresses)
+	req.TokenPaths = v.RealmPaths("tokenPaths", req.TokenPaths)
+	if writeValidation(c, v) {
+		return
+	}
+
+	resp, err := b.service.QueryBalances(c, b.chainID(c), req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"log/slog"
+	"net/http"
+	"onbloc/internal/apperror"
+	"onbloc/internal/logging"
+	"onbloc/internal/middleware"
+	"onbloc/internal/response"
+	"onbloc/internal/validation"
+	"strings"
+)
+
+var statusByCode = map[apperror.Code]int{
+	apperror.CodeNotFound:        http.StatusNotFound,
+	apperror.CodeInvalidArgument: http.StatusBadRequest,
+	apperror.CodeUnavailable:     http.StatusServiceUnavailable,
+	apperror.CodeUnauthenticated: http.StatusUnauthorized,
+	apperror.CodeInternal:        http.StatusInternalServerError,
+}
+
+// writeError 는 도메인 오류를 HTTP 상태 코드와 공통 오류 응답으로 변환한다.
+// INTERNAL/UNAVAILABLE 오류의 상세 내용은 응답에 노출하지 않고 로그로 남긴다. (request id 는 요청 ctx 에 담겨 있다.)
+func writeError(c *gin.Context, err error) {
+	code := apperror.CodeOf(err)
+	body := response.ErrorBody{
+		Code:      string(code),
+		Message:   publicMessage(c, code, err),
+		RequestID: middleware.GetRequestID(c),
+	}
+	var validationErr *apperror.ValidationError
+	if errors.As(err, &validationErr) {
+		body.Message = "validation failed"
+		for _, field := range validationErr.Fields {
+			body.Fields = append(body.Fields, response.FieldError{Field: field.Field, Message: field.Message})
+		}
+	}
+	c.AbortWithStatusJSON(statusByCode[code], response.ErrorResponse{Error: body})
+}
+
+// publicMessage 는 응답에 담을 오류 메시지이다. INTERNAL/UNAVAILABLE 은 상세 내용을 로그로만 남기고 고정된 메시지를 반환한다.
+func publicMessage(c *gin.Context, code apperror.Code, err error) string {
+	switch code {
+	case apperror.CodeInternal:
+		slog.ErrorContext(c, "request failed", "method", c.Request.Method, "path", c.Request.URL.Path, logging.Err(err))
+		return "internal server error"
+	case apperror.CodeUnavailable:
+		slog.WarnContext(c, "dependency unavailable", "method", c.Request.Method, "path", c.Request.URL.Path, logging.Err(err))
+		return "service temporarily unavailable"
+	}
+	return err.Error()
+}
+
+// WriteError 는 라우터처럼 핸들러 밖에서 생긴 오류를 공통 오류 응답으로 쓴다.
+func WriteError(c *gin.Context, err error) {
+	writeError(c, err)
+}
+
+// writeBindError 는 gin binding 태그 검증 실패를 필드별 오류로 변환한다.
+func writeBindError(c *gin.Context, err error) {
+	var bindingErrs validator.ValidationErrors
+	if !errors.As(err, &bindingErrs) {
+		writeInvalidArgument(c, err.Error())
+		return
+	}
+
+	validationErr := &apperror.ValidationError{}
+	for _, fieldErr := range bindingErrs {
+		validationErr.Fields = append(validationErr.Fields, apperror.FieldError{
+			Field:   jsonFieldName(fieldErr.Namespace()),
+			Message: fmt.Sprintf("failed on %s", fieldErr.Tag()),
+		})
+	}
+	writeError(c, validationErr)
+}
+
+// jsonFieldName 은 BalanceQueryRequest.Addresses[0] 을 addresses[0] 으로 바꾼다.
+func jsonFieldName(namespace string) string {
+	if i := strings.IndexByte(namespace, '.'); i >= 0 {
+		namespace = namespace[i+1:]
+	}
+	if namespace == "" {
+		return namespace
+	}
+	return strings.ToLower(namespace[:1]) + namespace[1:]
+}
+
+// writeValidation 은 검증 오류가 있으면 응답을 쓰고 true 를 반환한다.
+func writeValidation(c *gin.Context, v *validation.Validator) bool {
+	if err := v.Err(); err != nil {
+		writeError(c, err)
+		return true
+	}
+	return false
+}
+
+func writeInvalidArgument(c *gin.Context, message string) {
+	writeError(c, fmt.Errorf("%s: %w", message, apperror.ErrInvalidArgument))
+}
+
+// NotFound 는 등록되지 않은 라우트에 공통 오류 응답으로 404 를 반환한다.
+func NotFound(c *gin.Context) {
+	writeError(c, fmt.Errorf("route %s %s: %w", c.Request.Method, c.Request.URL.Path, apperror.ErrNotFound))
+}
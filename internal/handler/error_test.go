@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"onbloc/internal/apperror"
+	"onbloc/internal/middleware"
+	"onbloc/internal/response"
+	"strings"
+	"testing"
+)
+
+func serveError(t *testing.T, err error) (*httptest.ResponseRecorder, response.ErrorResponse) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.RequestID())
+	r.GET("/", func(c *gin.Context) {
+		writeError(c, err)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	r.ServeHTTP(w, req)
+
+	var body response.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w, body
+}
+
+func TestWriteError(t *testing.T) {
+	t.Run("ErrNotFound 는 404 를 반환한다", func(t *testing.T) {
+		w, body := serveError(t, fmt.Errorf("block 1: %w", apperror.ErrNotFound))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, response.ErrorBody{Code: "NOT_FOUND", Message: "block 1: not found", RequestID: "req-1"}, body.Error)
+	})
+
+	t.Run("ErrInvalidArgument 는 400 을 반환한다", func(t *testing.T) {
+		w, body := serveError(t, fmt.Errorf("invalid cursor: %w", apperror.ErrInvalidArgument))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "INVALID_ARGUMENT", body.Error.Code)
+	})
+
+	t.Run("ErrUnavailable 는 503 을 반환하고 상세 내용을 숨긴다", func(t *testing.T) {
+		w, body := serveError(t, fmt.Errorf("%w: dial tcp: connection refused", apperror.ErrUnavailable))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, response.ErrorBody{Code: "UNAVAILABLE", Message: "service temporarily unavailable", RequestID: "req-1"}, body.Error)
+	})
+
+	t.Run("그 외 오류는 500 을 반환하고 상세 내용을 숨긴다", func(t *testing.T) {
+		w, body := serveError(t, errors.New("pq: relation does not exist"))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, response.ErrorBody{Code: "INTERNAL", Message: "internal server error", RequestID: "req-1"}, body.Error)
+	})
+}
+
+func TestBalanceAPIHandler_InvalidArgument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewBalanceAPIHandler(nil, "dev")
+	r := gin.New()
+	r.GET("/blocks/:height", handler.GetBlock)
+	r.GET("/webhooks/:id", handler.GetWebhookSubscription)
+
+	for _, path := range []string{"/blocks/abc", "/webhooks/abc"} {
+		t.Run(path+" 는 400 을 반환한다", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+			var body response.ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "INVALID_ARGUMENT", body.Error.Code)
+		})
+	}
+}
+
+func TestBalanceAPIHandler_Validation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewBalanceAPIHandler(nil, "dev")
+	r := gin.New()
+	r.GET("/tokens/:tokenPath/balances", handler.GetTokenPathBalances)
+	r.POST("/balances/query", handler.QueryBalances)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		fields []response.FieldError
+	}{
+		{
+			name:   "잘못된 토큰 경로와 주소",
+			method: http.MethodGet,
+			path:   "/tokens/foo/balances?address=g1abc",
+			fields: []response.FieldError{
+				{Field: "tokenPath", Message: "is not a valid realm path"},
+				{Field: "address", Message: "is not a valid bech32 address: invalid separator position"},
+			},
+		},
+		{
+			name:   "빈 토큰 경로",
+			method: http.MethodGet,
+			path:   "/tokens/%20/balances",
+			fields: []response.FieldError{{Field: "tokenPath", Message: "is required"}},
+		},
+		{
+			name:   "주소 목록 누락",
+			method: http.MethodPost,
+			path:   "/balances/query",
+			body:   `{"tokenPaths": []}`,
+			fields: []response.FieldError{{Field: "addresses", Message: "failed on required"}},
+		},
+		{
+			name:   "주소 목록의 잘못된 주소",
+			method: http.MethodPost,
+			path:   "/balances/query",
+			body:   `{"addresses": ["g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf6"]}`,
+			fields: []response.FieldError{{Field: "addresses[0]", Message: "is not a valid bech32 address: invalid checksum"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			var body response.ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, "INVALID_ARGUMENT", body.Error.Code)
+			assert.Equal(t, tt.fields, body.Error.Fields)
+		})
+	}
+}
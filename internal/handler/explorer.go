@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"onbloc/internal/validation"
+	"strconv"
+	"strings"
+)
+
+func (b BalanceAPIHandler) GetBlocks(c *gin.Context) {
+	offset, limit := pagination(c)
+	resp, err := b.service.GetBlocks(c, b.chainID(c), offset, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (b BalanceAPIHandler) GetBlock(c *gin.Context) {
+	height, err := strconv.ParseInt(c.Param("height"), 10, 64)
+	if err != nil {
+		writeInvalidArgument(c, "invalid height")
+		return
+	}
+
+	resp, err := b.service.GetBlock(c, b.chainID(c), height)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (b BalanceAPIHandler) GetBlockTransactions(c *gin.Context) {
+	height, err := strconv.ParseInt(c.Param("height"), 10, 64)
+	if err != nil {
+		writeInvalidArgument(c, "invalid height")
+		return
+	}
+
+	resp, err := b.service.GetBlockTransactions(c, b.chainID(c), height)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetTransaction 의 hash 는 base64 라 슬래시가 포함될 수 있어 와일드카드로 받는다.
+func (b BalanceAPIHandler) GetTransaction(c *gin.Context) {
+	hash := strings.TrimPrefix(c.Param("hash"), "/")
+	if hash == "" {
+		writeInvalidArgument(c, "invalid hash")
+		return
+	}
+
+	resp, err := b.service.GetTransaction(c, b.chainID(c), hash)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (b BalanceAPIHandler) GetAccountTransactions(c *gin.Context) {
+	v := validation.New()
+	address := v.Address("address", c.Param("address"))
+	if writeValidation(c, v) {
+		return
+	}
+	offset, limit := pagination(c)
+	resp, err := b.service.GetAccountTransactions(c, b.chainID(c), address, offset, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetAccountActivities 는 types=transfer_in,mint 처럼 콤마로 구분된 타입 필터와 cursor 를 받는다.
+func (b BalanceAPIHandler) GetAccountActivities(c *gin.Context) {
+	v := validation.New()
+	address := v.Address("address", c.Param("address"))
+	if writeValidation(c, v) {
+		return
+	}
+	var types []string
+	if raw := c.Query("types"); raw != "" {
+		types = strings.Split(raw, ",")
+	}
+	_, limit := pagination(c)
+
+	resp, err := b.service.GetAccountActivities(c, b.chainID(c), address, types, c.Query("cursor"), limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func pagination(c *gin.Context) (offset, limit int) {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	offset, err = strconv.Atoi(c.Query("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return offset, limit
+}
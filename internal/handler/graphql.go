@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"net/http"
+	"onbloc/internal/apperror"
+	graphql_api "onbloc/internal/graphql-api"
+)
+
+type GraphQLHandler struct {
+	schema         graphql.Schema
+	defaultChainID string
+}
+
+func NewGraphQLHandler(schema graphql.Schema, defaultChainID string) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema:         schema,
+		defaultChainID: defaultChainID,
+	}
+}
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query" form:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+}
+
+// Query 는 POST(JSON body) 와 GET(?query=) 요청을 모두 처리한다.
+func (h GraphQLHandler) Query(c *gin.Context) {
+	var req GraphQLRequest
+	if err := c.ShouldBind(&req); err != nil {
+		writeInvalidArgument(c, err.Error())
+		return
+	}
+	if req.Query == "" {
+		writeInvalidArgument(c, "query is required")
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        graphql_api.WithChainID(c, chainIDOrDefault(c, h.defaultChainID)),
+	})
+	result.Errors = formatGraphQLErrors(c, result.Errors)
+	c.JSON(http.StatusOK, result)
+}
+
+// formatGraphQLErrors 는 REST 와 같은 오류 코드를 extensions.code 에 담고, INTERNAL/UNAVAILABLE 의 상세 내용은 로그로만 남긴다.
+// 구문/검증 오류처럼 실행 전에 생긴 오류는 INVALID_ARGUMENT, resolver 가 아닌 실행 중 오류(null 불가 필드 등)는 INTERNAL 이다.
+func formatGraphQLErrors(c *gin.Context, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	for i, formatted := range errs {
+		var err error
+		if located, ok := formatted.OriginalError().(*gqlerrors.Error); ok && located.OriginalError != nil {
+			err = located.OriginalError
+		} else if len(formatted.Path) > 0 {
+			err = errors.New(formatted.Message)
+		}
+
+		code := apperror.CodeInvalidArgument
+		if err != nil {
+			code = apperror.CodeOf(err)
+			errs[i].Message = publicMessage(c, code, err)
+		}
+		extensions := make(map[string]interface{}, len(formatted.Extensions)+1)
+		for key, value := range formatted.Extensions {
+			extensions[key] = value
+		}
+		extensions["code"] = string(code)
+		errs[i].Extensions = extensions
+	}
+	return errs
+}
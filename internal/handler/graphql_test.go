@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"onbloc/internal/apperror"
+	graphql_api "onbloc/internal/graphql-api"
+	"onbloc/internal/repository/postgresdb"
+	balance_api_service "onbloc/internal/service/balance-api-service"
+	"testing"
+)
+
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func serveGraphQL(t *testing.T, schema graphql.Schema, query string) (*httptest.ResponseRecorder, graphQLResponse) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/graphql", NewGraphQLHandler(schema, "dev").Query)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(query), nil))
+
+	var body graphQLResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w, body
+}
+
+func TestGraphQLHandler_Errors(t *testing.T) {
+	t.Run("저장소에 연결하지 못하면 상세 내용 없이 UNAVAILABLE 을 반환한다", func(t *testing.T) {
+		// 열려 있지 않은 포트라 모든 쿼리가 연결 오류로 실패한다.
+		db, err := gorm.Open(postgres.Open("host=127.0.0.1 port=1 user=postgres password=secret dbname=onbloc sslmode=disable connect_timeout=1"),
+			&gorm.Config{DisableAutomaticPing: true})
+		require.NoError(t, err)
+		schema, err := graphql_api.NewSchema(balance_api_service.NewService(postgresdb.NewRepository(db), nil, 0))
+		require.NoError(t, err)
+
+		w, body := serveGraphQL(t, schema, `{ block(height: 1) { hash } }`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, body.Errors, 1)
+		assert.Equal(t, "service temporarily unavailable", body.Errors[0].Message)
+		assert.Equal(t, "UNAVAILABLE", body.Errors[0].Extensions["code"])
+		assert.NotContains(t, w.Body.String(), "127.0.0.1")
+	})
+
+	resolverErr := errors.New("pq: relation \"balances\" does not exist")
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"failing": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return nil, resolverErr
+			}},
+			"missing": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return nil, fmt.Errorf("block 1: %w", apperror.ErrNotFound)
+			}},
+		},
+	})})
+	require.NoError(t, err)
+
+	t.Run("그 외 resolver 오류는 상세 내용 없이 INTERNAL 을 반환한다", func(t *testing.T) {
+		w, body := serveGraphQL(t, schema, `{ failing }`)
+		require.Len(t, body.Errors, 1)
+		assert.Equal(t, "internal server error", body.Errors[0].Message)
+		assert.Equal(t, "INTERNAL", body.Errors[0].Extensions["code"])
+		assert.NotContains(t, w.Body.String(), "relation")
+	})
+
+	t.Run("도메인 오류는 메시지와 코드를 그대로 반환한다", func(t *testing.T) {
+		_, body := serveGraphQL(t, schema, `{ missing }`)
+		require.Len(t, body.Errors, 1)
+		assert.Equal(t, "block 1: not found", body.Errors[0].Message)
+		assert.Equal(t, "NOT_FOUND", body.Errors[0].Extensions["code"])
+	})
+
+	t.Run("질의 검증 오류는 INVALID_ARGUMENT 이다", func(t *testing.T) {
+		_, body := serveGraphQL(t, schema, `{ unknown }`)
+		require.NotEmpty(t, body.Errors)
+		assert.Contains(t, body.Errors[0].Message, "unknown")
+		assert.Equal(t, "INVALID_ARGUMENT", body.Errors[0].Extensions["code"])
+	})
+}
@@ -0,0 +1,15 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (b BalanceAPIHandler) Search(c *gin.Context) {
+	resp, err := b.service.Search(c, b.chainID(c), c.Query("q"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
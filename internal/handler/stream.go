@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"log/slog"
+	"net/http"
+	"onbloc/internal/logging"
+	"onbloc/internal/stream"
+	"onbloc/internal/validation"
+	"strings"
+	"time"
+)
+
+const (
+	streamPingInterval = 30 * time.Second
+	streamWriteTimeout = 10 * time.Second
+)
+
+type StreamHandler struct {
+	hub            *stream.Hub
+	defaultChainID string
+	upgrader       websocket.Upgrader
+}
+
+func NewStreamHandler(hub *stream.Hub, defaultChainID string) *StreamHandler {
+	return &StreamHandler{
+		hub:            hub,
+		defaultChainID: defaultChainID,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// StreamCommand 는 WebSocket 클라이언트가 구독 대상을 변경할 때 보내는 메시지이다.
+type StreamCommand struct {
+	Action    string   `json:"action"`
+	Addresses []string `json:"addresses"`
+	Tokens    []string `json:"tokens"`
+}
+
+// WebSocket 은 /stream/ws?addresses=&tokens= 로 연결하며, 연결 후 StreamCommand 로 구독을 추가/해제할 수 있다.
+func (h StreamHandler) WebSocket(c *gin.Context) {
+	addresses, tokens, err := streamFilters(splitQuery(c, "addresses"), splitQuery(c, "tokens"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		slog.WarnContext(c, "websocket upgrade failed", logging.Err(err))
+		return
+	}
+	defer conn.Close()
+
+	subscriber := h.hub.Subscribe(chainIDOrDefault(c, h.defaultChainID), addresses, tokens)
+	defer h.hub.Unsubscribe(subscriber)
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var command StreamCommand
+			if err := conn.ReadJSON(&command); err != nil {
+				return
+			}
+			addresses, tokens, err := streamFilters(command.Addresses, command.Tokens)
+			if err != nil {
+				slog.DebugContext(c, "stream command ignored", logging.Err(err))
+				continue
+			}
+			switch command.Action {
+			case "subscribe":
+				subscriber.Subscribe(addresses, tokens)
+			case "unsubscribe":
+				subscriber.Unsubscribe(addresses, tokens)
+			}
+		}
+	}()
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case message, ok := <-subscriber.Messages():
+			if !ok {
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err = conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ServerSentEvents 는 /stream/sse?addresses=&tokens= 로 연결하며, 메시지 타입(transfer, balance)을 event 이름으로 보낸다.
+func (h StreamHandler) ServerSentEvents(c *gin.Context) {
+	addresses, tokens, err := streamFilters(splitQuery(c, "addresses"), splitQuery(c, "tokens"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	subscriber := h.hub.Subscribe(chainIDOrDefault(c, h.defaultChainID), addresses, tokens)
+	defer h.hub.Unsubscribe(subscriber)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ping := time.NewTicker(streamPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case message, ok := <-subscriber.Messages():
+			if !ok {
+				return
+			}
+			c.SSEvent(message.Type, message)
+			c.Writer.Flush()
+		case <-ping.C:
+			_, _ = c.Writer.WriteString(": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func splitQuery(c *gin.Context, key string) []string {
+	var values []string
+	for _, value := range strings.Split(c.Query(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func streamFilters(addresses, tokens []string) ([]string, []string, error) {
+	v := validation.New()
+	addresses = v.Addresses("addresses", addresses)
+	tokens = v.RealmPaths("tokens", tokens)
+	return addresses, tokens, v.Err()
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"onbloc/internal/validation"
+	"onbloc/pkg/model"
+	"strconv"
+	"time"
+)
+
+// GetTokenVolume 은 /tokens/{path}/volume?interval=1h&from=&to= 요청을 처리한다.
+// from, to 는 RFC3339 또는 unix seconds 이며, 기본값은 to=현재, from=to-(버킷 24개)이다.
+func (b BalanceAPIHandler) GetTokenVolume(c *gin.Context) {
+	v := validation.New()
+	tokenPath := v.RealmPath("tokenPath", c.Param("tokenPath"))
+	if writeValidation(c, v) {
+		return
+	}
+
+	interval := c.DefaultQuery("interval", model.VolumeIntervalHour)
+	duration, exists := model.VolumeIntervals[interval]
+	if !exists {
+		writeInvalidArgument(c, "invalid interval")
+		return
+	}
+
+	to, err := parseTime(c.Query("to"), time.Now())
+	if err != nil {
+		writeInvalidArgument(c, "invalid to")
+		return
+	}
+	from, err := parseTime(c.Query("from"), to.Add(-24*duration))
+	if err != nil {
+		writeInvalidArgument(c, "invalid from")
+		return
+	}
+
+	resp, err := b.service.GetTokenVolume(c, b.chainID(c), tokenPath, interval, from, to)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func parseTime(value string, defaultValue time.Time) (time.Time, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"onbloc/internal/request"
+	"onbloc/internal/validation"
+	"strconv"
+)
+
+func (b BalanceAPIHandler) CreateWebhookSubscription(c *gin.Context) {
+	var req request.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+	if !validateWebhookRequest(c, &req) {
+		return
+	}
+
+	resp, err := b.service.CreateWebhookSubscription(c, b.chainID(c), req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (b BalanceAPIHandler) GetWebhookSubscriptions(c *gin.Context) {
+	resp, err := b.service.GetWebhookSubscriptions(c, b.chainID(c))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (b BalanceAPIHandler) GetWebhookSubscription(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		writeInvalidArgument(c, "invalid id")
+		return
+	}
+
+	resp, err := b.service.GetWebhookSubscription(c, b.chainID(c), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (b BalanceAPIHandler) UpdateWebhookSubscription(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		writeInvalidArgument(c, "invalid id")
+		return
+	}
+	var req request.WebhookSubscriptionRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		writeBindError(c, err)
+		return
+	}
+	if !validateWebhookRequest(c, &req) {
+		return
+	}
+
+	resp, err := b.service.UpdateWebhookSubscription(c, b.chainID(c), id, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (b BalanceAPIHandler) DeleteWebhookSubscription(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		writeInvalidArgument(c, "invalid id")
+		return
+	}
+
+	if err = b.service.DeleteWebhookSubscription(c, b.chainID(c), id); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (b BalanceAPIHandler) GetWebhookDeliveries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		writeInvalidArgument(c, "invalid id")
+		return
+	}
+	offset, limit := pagination(c)
+
+	resp, err := b.service.GetWebhookDeliveries(c, b.chainID(c), id, offset, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func validateWebhookRequest(c *gin.Context, req *request.WebhookSubscriptionRequest) bool {
+	v := validation.New()
+	req.URL = v.WebhookURL("url", req.URL)
+	req.Address = v.OptionalAddress("address", req.Address)
+	req.TokenPath = v.OptionalRealmPath("tokenPath", req.TokenPath)
+	return !writeValidation(c, v)
+}
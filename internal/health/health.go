@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+
+	defaultCheckTimeout = 3 * time.Second
+)
+
+// Check 는 의존성이나 내부 상태를 확인하고, 정상이 아니면 오류를 반환한다.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Report 는 /healthz, /readyz 의 응답이다. Checks 는 확인 항목별 "ok" 또는 오류 메시지이다.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Checker 는 liveness(/healthz) 와 readiness(/readyz) 확인 항목을 모아 실행한다.
+type Checker struct {
+	liveness  []namedCheck
+	readiness []namedCheck
+	timeout   time.Duration
+}
+
+func NewChecker() *Checker {
+	return &Checker{timeout: defaultCheckTimeout}
+}
+
+// AddLiveness 는 실패하면 프로세스를 재시작해야 하는 항목을 등록한다. (멈춘 동기화/처리 등)
+func (c *Checker) AddLiveness(name string, check Check) {
+	c.liveness = append(c.liveness, namedCheck{name: name, check: check})
+}
+
+// AddReadiness 는 실패하면 트래픽/작업을 받지 않아야 하는 항목을 등록한다. (Postgres, 큐, Redis 등)
+func (c *Checker) AddReadiness(name string, check Check) {
+	c.readiness = append(c.readiness, namedCheck{name: name, check: check})
+}
+
+func (c *Checker) Liveness(w http.ResponseWriter, r *http.Request) {
+	c.write(w, c.run(r.Context(), c.liveness))
+}
+
+func (c *Checker) Readiness(w http.ResponseWriter, r *http.Request) {
+	c.write(w, c.run(r.Context(), c.readiness))
+}
+
+// run 은 모든 항목을 동시에 실행하며, 각 항목은 timeout 안에 끝나야 한다.
+func (c *Checker) run(ctx context.Context, checks []namedCheck) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]string, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := check.check(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.Status = StatusFail
+				report.Checks[check.name] = err.Error()
+				return
+			}
+			report.Checks[check.name] = StatusOK
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+func (c *Checker) write(w http.ResponseWriter, report Report) {
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
+
+// Postgres 는 커넥션 풀에서 DB 에 ping 한다.
+func Postgres(db *sql.DB) Check {
+	return db.PingContext
+}
@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChecker(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	fail := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	t.Run("모든 항목이 정상이면 200 을 반환한다", func(t *testing.T) {
+		checker := NewChecker()
+		checker.AddReadiness("postgres", ok)
+		checker.AddReadiness("queue", ok)
+
+		report, status := perform(t, checker.Readiness)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, Report{Status: StatusOK, Checks: map[string]string{"postgres": StatusOK, "queue": StatusOK}}, report)
+	})
+
+	t.Run("하나라도 실패하면 503 과 오류 메시지를 반환한다", func(t *testing.T) {
+		checker := NewChecker()
+		checker.AddReadiness("postgres", ok)
+		checker.AddReadiness("redis", fail)
+
+		report, status := perform(t, checker.Readiness)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, StatusFail, report.Status)
+		assert.Equal(t, "connection refused", report.Checks["redis"])
+	})
+
+	t.Run("시간 안에 끝나지 않는 항목은 실패한다", func(t *testing.T) {
+		checker := NewChecker()
+		checker.timeout = 10 * time.Millisecond
+		checker.AddReadiness("tx-indexer", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		report, status := perform(t, checker.Readiness)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["tx-indexer"])
+	})
+
+	t.Run("liveness 와 readiness 는 따로 확인한다", func(t *testing.T) {
+		checker := NewChecker()
+		checker.AddReadiness("postgres", fail)
+
+		report, status := perform(t, checker.Liveness)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Empty(t, report.Checks)
+	})
+}
+
+func perform(t *testing.T, handler http.HandlerFunc) (Report, int) {
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var report Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	return report, w.Code
+}
@@ -0,0 +1,102 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Heartbeat 는 반복 작업이 마지막으로 정상 진행된 시각을 기록한다.
+type Heartbeat struct {
+	mu   sync.Mutex
+	last time.Time
+	now  func() time.Time
+}
+
+func NewHeartbeat() *Heartbeat {
+	return &Heartbeat{last: time.Now(), now: time.Now}
+}
+
+func (h *Heartbeat) Beat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.last = h.now()
+}
+
+// Check 는 마지막 진행 이후 stallTimeout 이 지나면 실패한다.
+func (h *Heartbeat) Check(stallTimeout time.Duration) Check {
+	return func(ctx context.Context) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if elapsed := h.now().Sub(h.last); elapsed > stallTimeout {
+			return fmt.Errorf("no progress for %s", elapsed.Truncate(time.Second))
+		}
+		return nil
+	}
+}
+
+// SyncProgress 는 체인 동기화의 지연(indexer 높이 − 저장 높이)과 진행 시각을 기록한다.
+type SyncProgress struct {
+	mu           sync.Mutex
+	standby      bool
+	lag          int64
+	storedHeight int64
+	lastObserved time.Time
+	lastAdvanced time.Time
+	now          func() time.Time
+}
+
+func NewSyncProgress() *SyncProgress {
+	now := time.Now()
+	return &SyncProgress{lastObserved: now, lastAdvanced: now, now: time.Now}
+}
+
+// Observe 는 높이를 확인할 때마다 호출된다. 저장 높이가 올라갔거나 지연이 없으면 진행한 것으로 본다.
+func (p *SyncProgress) Observe(storedHeight, indexerHeight int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.lastObserved = now
+	p.lag = indexerHeight - storedHeight
+	if storedHeight > p.storedHeight || p.lag <= 0 {
+		p.lastAdvanced = now
+	}
+	p.storedHeight = storedHeight
+}
+
+// SetStandby 는 리더가 아니어서 동기화하지 않는 동안 true 로 둔다. 대기 중에는 진행하지 않아도 정상이며,
+// 리더가 되면 그 시점부터 진행 여부를 다시 잰다.
+func (p *SyncProgress) SetStandby(standby bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.standby = standby
+	if !standby {
+		now := p.now()
+		p.lastObserved = now
+		p.lastAdvanced = now
+	}
+}
+
+// Check 는 stallTimeout 동안 높이를 확인하지 못했거나, 지연이 maxLag 를 넘은 채 stallTimeout 동안 진행하지 못하면 실패한다.
+// maxLag 가 0 이하이면 지연은 확인하지 않는다.
+func (p *SyncProgress) Check(maxLag int64, stallTimeout time.Duration) Check {
+	return func(ctx context.Context) error {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		if p.standby {
+			return nil
+		}
+		now := p.now()
+		if elapsed := now.Sub(p.lastObserved); elapsed > stallTimeout {
+			return fmt.Errorf("sync loop stalled for %s", elapsed.Truncate(time.Second))
+		}
+		if maxLag > 0 && p.lag > maxLag && now.Sub(p.lastAdvanced) > stallTimeout {
+			return fmt.Errorf("lag %d blocks exceeds %d without progress for %s", p.lag, maxLag, now.Sub(p.lastAdvanced).Truncate(time.Second))
+		}
+		return nil
+	}
+}
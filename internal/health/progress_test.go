@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat(t *testing.T) {
+	now := time.Now()
+	heartbeat := NewHeartbeat()
+	heartbeat.now = func() time.Time { return now }
+	heartbeat.Beat()
+	check := heartbeat.Check(time.Minute)
+
+	assert.NoError(t, check(context.Background()))
+
+	now = now.Add(2 * time.Minute)
+	assert.Error(t, check(context.Background()))
+
+	heartbeat.Beat()
+	assert.NoError(t, check(context.Background()))
+}
+
+func TestSyncProgress(t *testing.T) {
+	now := time.Now()
+	newProgress := func() *SyncProgress {
+		progress := NewSyncProgress()
+		progress.now = func() time.Time { return now }
+		return progress
+	}
+
+	t.Run("높이를 확인하지 못한 채 시간이 지나면 실패한다", func(t *testing.T) {
+		progress := newProgress()
+		progress.Observe(100, 100)
+		check := progress.Check(0, time.Minute)
+
+		now = now.Add(2 * time.Minute)
+		assert.ErrorContains(t, check(context.Background()), "stalled")
+	})
+
+	t.Run("지연이 크더라도 저장 높이가 오르고 있으면 정상이다", func(t *testing.T) {
+		progress := newProgress()
+		check := progress.Check(10, time.Minute)
+		for height := int64(100); height < 500; height += 100 {
+			progress.Observe(height, 10000)
+			now = now.Add(30 * time.Second)
+			assert.NoError(t, check(context.Background()))
+		}
+	})
+
+	t.Run("지연이 기준을 넘은 채 진행하지 못하면 실패한다", func(t *testing.T) {
+		progress := newProgress()
+		check := progress.Check(10, time.Minute)
+		progress.Observe(100, 200)
+		for i := 0; i < 3; i++ {
+			now = now.Add(30 * time.Second)
+			progress.Observe(100, 200)
+		}
+		assert.ErrorContains(t, check(context.Background()), "lag 100 blocks")
+	})
+
+	t.Run("maxLag 가 0 이면 지연은 확인하지 않는다", func(t *testing.T) {
+		progress := newProgress()
+		check := progress.Check(0, time.Minute)
+		progress.Observe(100, 200)
+		now = now.Add(50 * time.Second)
+		progress.Observe(100, 200)
+		now = now.Add(50 * time.Second)
+		progress.Observe(100, 200)
+		assert.NoError(t, check(context.Background()))
+	})
+
+	t.Run("대기 중에는 진행하지 않아도 정상이고, 리더가 되면 다시 잰다", func(t *testing.T) {
+		progress := newProgress()
+		check := progress.Check(0, time.Minute)
+		progress.SetStandby(true)
+		now = now.Add(10 * time.Minute)
+		assert.NoError(t, check(context.Background()))
+
+		progress.SetStandby(false)
+		assert.NoError(t, check(context.Background()))
+		now = now.Add(2 * time.Minute)
+		assert.ErrorContains(t, check(context.Background()), "stalled")
+	})
+}
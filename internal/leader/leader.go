@@ -0,0 +1,145 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"onbloc/internal/logging"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrLostLease 는 임기의 임대가 만료되었거나 다른 인스턴스에게 넘어갔을 때 Verify 가 반환한다.
+var ErrLostLease = errors.New("lost leader lease")
+
+// LeaseStore 는 임대를 보관한다. postgresdb.Repository 가 구현하며, 테스트에서는 메모리 구현을 사용한다.
+type LeaseStore interface {
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (token int64, acquired bool, err error)
+	RenewLease(ctx context.Context, name, holder string, token int64, ttl time.Duration) (renewed bool, err error)
+	ReleaseLease(ctx context.Context, name, holder string, token int64) error
+}
+
+// LeadFunc 는 리더인 동안 실행된다. ctx 는 리더 자격을 잃거나 종료할 때 취소되며, token 은 이번 임기의 펜싱 토큰이다.
+type LeadFunc func(ctx context.Context, token int64)
+
+// Elector 는 이름(name)마다 하나의 인스턴스만 리더가 되도록 임대를 얻고 ttl/3 마다 연장한다.
+// 리더가 죽으면 임대가 ttl 안에 만료되어 대기 중인 인스턴스가 다음 시도(ttl/3 이내)에서 이어받는다.
+type Elector struct {
+	store    LeaseStore
+	name     string
+	holder   string
+	ttl      time.Duration
+	interval time.Duration
+	now      func() time.Time
+}
+
+func NewElector(store LeaseStore, name string, ttl time.Duration) *Elector {
+	return &Elector{
+		store:    store,
+		name:     name,
+		holder:   Holder(),
+		ttl:      ttl,
+		interval: ttl / 3,
+		now:      time.Now,
+	}
+}
+
+// Holder 는 프로세스를 구분하는 임대 보유자 이름이다. (호스트명-pid-임의값)
+func Holder() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewString()[:8])
+}
+
+// Run 은 ctx 가 취소될 때까지 리더 선출에 참여한다. 리더가 되면 lead 를 실행하고,
+// 임대를 잃거나 lead 가 끝나면 다시 대기한다. 종료할 때는 lead 가 끝나기를 기다린 뒤 임대를 반납한다.
+func (e *Elector) Run(ctx context.Context, lead LeadFunc) {
+	ctx = logging.With(ctx, "lease", e.name, "holder", e.holder)
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		token, acquired, err := e.store.AcquireLease(ctx, e.name, e.holder, e.ttl)
+		if err != nil && ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to acquire lease", logging.Err(err))
+		}
+		if acquired {
+			e.lead(ctx, token, lead)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Verify 는 token 임기의 임대를 연장해 보며 아직 리더인지 확인하고, 리더가 아니면 ErrLostLease 를 반환한다.
+// 리더 ctx 취소는 진행 중인 구간을 멈추지 않으므로, 이벤트 발행처럼 되돌릴 수 없는 작업 직전에 호출한다.
+func (e *Elector) Verify(ctx context.Context, token int64) error {
+	renewed, err := e.store.RenewLease(ctx, e.name, e.holder, token, e.ttl)
+	if err != nil {
+		return fmt.Errorf("failed to verify lease: %w", err)
+	}
+	if !renewed {
+		return ErrLostLease
+	}
+	return nil
+}
+
+func (e *Elector) lead(ctx context.Context, token int64, lead LeadFunc) {
+	slog.InfoContext(ctx, "became leader", "fencing_token", token)
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		lead(leaderCtx, token)
+	}()
+
+	e.keepAlive(leaderCtx, token)
+	cancel()
+	wg.Wait()
+
+	// 종료 중에도 반납은 해야 다른 인스턴스가 ttl 을 기다리지 않고 이어받는다.
+	if err := e.store.ReleaseLease(context.WithoutCancel(ctx), e.name, e.holder, token); err != nil {
+		slog.WarnContext(ctx, "failed to release lease", logging.Err(err))
+	}
+	slog.InfoContext(ctx, "stepped down", "fencing_token", token)
+}
+
+// keepAlive 는 leaderCtx 가 취소되거나 임대를 잃을 때까지 연장한다.
+// 연장 요청이 실패해도 마지막 연장 시각부터 ttl 이 지나기 전까지는 다시 시도하고, 그 뒤에는 임대가 만료된 것으로 보고 물러난다.
+func (e *Elector) keepAlive(leaderCtx context.Context, token int64) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	deadline := e.now().Add(e.ttl)
+	for {
+		select {
+		case <-leaderCtx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		renewedAt := e.now()
+		renewed, err := e.store.RenewLease(leaderCtx, e.name, e.holder, token, e.ttl)
+		switch {
+		case err != nil && leaderCtx.Err() == nil:
+			slog.WarnContext(leaderCtx, "failed to renew lease", logging.Err(err))
+			if !e.now().Before(deadline) {
+				slog.WarnContext(leaderCtx, "lease expired while renewal was failing")
+				return
+			}
+		case err == nil && !renewed:
+			slog.WarnContext(leaderCtx, "lost lease")
+			return
+		case err == nil:
+			deadline = renewedAt.Add(e.ttl)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package leader
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryStore 는 sync_leases 와 같은 규칙(만료된 임대만 넘기고, 넘길 때마다 토큰 증가)을 메모리에서 따른다.
+type memoryStore struct {
+	mu        sync.Mutex
+	holder    string
+	token     int64
+	expiresAt time.Time
+}
+
+func (s *memoryStore) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holder != "" && time.Now().Before(s.expiresAt) {
+		return 0, false, nil
+	}
+	s.holder, s.token, s.expiresAt = holder, s.token+1, time.Now().Add(ttl)
+	return s.token, true, nil
+}
+
+func (s *memoryStore) RenewLease(ctx context.Context, name, holder string, token int64, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holder != holder || s.token != token || time.Now().After(s.expiresAt) {
+		return false, nil
+	}
+	s.expiresAt = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (s *memoryStore) ReleaseLease(ctx context.Context, name, holder string, token int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holder == holder && s.token == token {
+		s.expiresAt = time.Now().Add(-time.Second)
+	}
+	return nil
+}
+
+// steal 은 다른 인스턴스가 임대를 가져간 상황을 만든다.
+func (s *memoryStore) steal() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.holder, s.token, s.expiresAt = "other", s.token+1, time.Now().Add(time.Hour)
+}
+
+const testTTL = 60 * time.Millisecond
+
+func TestElector_Run(t *testing.T) {
+	t.Run("한 번에 하나만 리더가 되고, 리더가 종료하면 대기 중인 인스턴스가 이어받는다", func(t *testing.T) {
+		store := &memoryStore{}
+		var leaders atomic.Int32
+		var overlapped atomic.Bool
+		tokens := make(chan int64, 2)
+		lead := func(ctx context.Context, token int64) {
+			if leaders.Add(1) > 1 {
+				overlapped.Store(true)
+			}
+			defer leaders.Add(-1)
+			tokens <- token
+			<-ctx.Done()
+		}
+
+		firstCtx, stopFirst := context.WithCancel(context.Background())
+		secondCtx, stopSecond := context.WithCancel(context.Background())
+		defer stopSecond()
+		firstDone := make(chan struct{})
+		go func() {
+			NewElector(store, "sync:dev", testTTL).Run(firstCtx, lead)
+			close(firstDone)
+		}()
+		first := <-tokens
+		go NewElector(store, "sync:dev", testTTL).Run(secondCtx, lead)
+
+		time.Sleep(3 * testTTL)
+		stopFirst()
+		<-firstDone
+
+		select {
+		case second := <-tokens:
+			assert.Greater(t, second, first)
+		case <-time.After(time.Second):
+			t.Fatal("standby did not take over")
+		}
+		assert.False(t, overlapped.Load())
+	})
+
+	t.Run("임대를 잃으면 리더 ctx 가 취소된다", func(t *testing.T) {
+		store := &memoryStore{}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stepped := make(chan struct{})
+		go NewElector(store, "sync:dev", testTTL).Run(ctx, func(ctx context.Context, token int64) {
+			store.steal()
+			<-ctx.Done()
+			close(stepped)
+			cancel()
+		})
+
+		select {
+		case <-stepped:
+		case <-time.After(time.Second):
+			t.Fatal("leader did not step down after losing the lease")
+		}
+	})
+
+	t.Run("임기 중 임대를 잃으면 Verify 가 ErrLostLease 를 반환한다", func(t *testing.T) {
+		store := &memoryStore{}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		elector := NewElector(store, "sync:dev", time.Hour)
+		errs := make(chan error, 2)
+		go elector.Run(ctx, func(ctx context.Context, token int64) {
+			errs <- elector.Verify(ctx, token)
+			store.steal()
+			errs <- elector.Verify(ctx, token)
+			cancel()
+		})
+
+		assert.NoError(t, <-errs)
+		assert.ErrorIs(t, <-errs, ErrLostLease)
+	})
+
+	t.Run("lead 가 끝나면 임대를 반납한다", func(t *testing.T) {
+		store := &memoryStore{}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		done := make(chan struct{})
+		go NewElector(store, "sync:dev", time.Hour).Run(ctx, func(ctx context.Context, token int64) {
+			close(done)
+		})
+		<-done
+		require.Eventually(t, func() bool {
+			_, acquired, _ := store.AcquireLease(context.Background(), "sync:dev", "other", time.Hour)
+			return acquired
+		}, time.Second, 5*time.Millisecond)
+	})
+}
@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+	"onbloc/internal/config"
+	"os"
+)
+
+// 서비스 간에 같은 이름으로 필터/조인할 수 있도록 공통 필드 이름을 고정한다.
+const (
+	KeyService    = "service"
+	KeyChainID    = "chain_id"
+	KeyFromHeight = "from_height"
+	KeyToHeight   = "to_height"
+	KeyTxHash     = "tx_hash"
+	KeyEventIndex = "event_index"
+	KeyMessageID  = "message_id"
+	KeyRequestID  = "request_id"
+	KeyTraceID    = "trace_id"
+	KeySpanID     = "span_id"
+	KeyError      = "error"
+)
+
+// Init 은 설정에 따라 기본 slog 로거를 만들고 service 필드를 붙인다.
+// slog.SetDefault 이후 표준 log 패키지 출력도 같은 핸들러로 기록된다.
+func Init(conf config.Logging, service string) (*slog.Logger, error) {
+	logger, err := New(os.Stdout, conf)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.With(KeyService, service)
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+func New(w io.Writer, conf config.Logging) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(conf.GetLevel())); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", conf.Level, err)
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch conf.GetFormat() {
+	case "text":
+		handler = slog.NewTextHandler(w, options)
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unsupported log format: %s", conf.Format)
+	}
+	return slog.New(contextHandler{Handler: handler}), nil
+}
+
+type contextKey struct{}
+
+// With 은 이후 ctx 로 기록하는 모든 로그에 붙을 필드를 추가한다.
+func With(ctx context.Context, args ...any) context.Context {
+	record := slog.Record{}
+	record.Add(args...)
+	attrs := append([]slog.Attr{}, attrsFrom(ctx)...)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	return context.WithValue(ctx, contextKey{}, attrs)
+}
+
+func attrsFrom(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	return attrs
+}
+
+// Err 는 오류를 공통 필드 이름으로 기록한다.
+func Err(err error) slog.Attr {
+	return slog.Any(KeyError, err)
+}
+
+// contextHandler 는 ctx 에 담긴 필드와 trace_id/span_id 를 로그에 붙인다.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.AddAttrs(attrsFrom(ctx)...)
+	if ctx != nil {
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			record.AddAttrs(
+				slog.String(KeyTraceID, spanContext.TraceID().String()),
+				slog.String(KeySpanID, spanContext.SpanID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"onbloc/internal/config"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("ctx 에 담은 필드를 JSON 로그에 붙인다", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, config.Logging{Format: "json"})
+		require.NoError(t, err)
+
+		ctx := With(context.Background(), KeyChainID, "dev", KeyFromHeight, int64(10))
+		ctx = With(ctx, KeyTxHash, "hash")
+		logger.InfoContext(ctx, "processed token event", KeyEventIndex, 1)
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, "processed token event", record["msg"])
+		assert.Equal(t, "dev", record[KeyChainID])
+		assert.Equal(t, float64(10), record[KeyFromHeight])
+		assert.Equal(t, "hash", record[KeyTxHash])
+		assert.Equal(t, float64(1), record[KeyEventIndex])
+	})
+
+	t.Run("span 이 있으면 trace_id 와 span_id 를 붙인다", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, config.Logging{Format: "json"})
+		require.NoError(t, err)
+
+		spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}, TraceFlags: trace.FlagsSampled})
+		logger.InfoContext(trace.ContextWithSpanContext(context.Background(), spanContext), "request")
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+		assert.Equal(t, spanContext.TraceID().String(), record[KeyTraceID])
+		assert.Equal(t, spanContext.SpanID().String(), record[KeySpanID])
+	})
+
+	t.Run("설정한 레벨보다 낮은 로그는 기록하지 않는다", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, config.Logging{Level: "warn"})
+		require.NoError(t, err)
+
+		logger.Info("skipped")
+		assert.Empty(t, buf.String())
+		logger.Warn("written")
+		assert.Contains(t, buf.String(), "level=WARN")
+	})
+
+	t.Run("잘못된 설정은 오류를 반환한다", func(t *testing.T) {
+		_, err := New(&bytes.Buffer{}, config.Logging{Level: "verbose"})
+		assert.Error(t, err)
+		_, err = New(&bytes.Buffer{}, config.Logging{Format: "xml"})
+		assert.Error(t, err)
+	})
+}
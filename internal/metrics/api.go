@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"strconv"
+	"time"
+)
+
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+var (
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "라우트/상태 코드별 HTTP 요청 시간",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "api",
+		Name:      "cache_requests_total",
+		Help:      "조회 종류별 응답 캐시 적중/미스/오류 수",
+	}, []string{"query", "result"})
+)
+
+// GinMiddleware 는 요청 시간을 등록된 라우트 경로 기준으로 기록한다. 등록되지 않은 경로는 "unmatched" 로 묶는다.
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		requestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+func IncCache(query, result string) {
+	cacheRequests.WithLabelValues(query, result).Inc()
+}
+
+// CacheRequests 는 조회 종류/결과별 캐시 카운터를 반환한다. 다른 패키지의 테스트에서 누적 값을 확인할 때 쓴다.
+func CacheRequests(query, result string) prometheus.Counter {
+	return cacheRequests.WithLabelValues(query, result)
+}
+
+// RegisterDBStats 는 커넥션 풀 상태(sql.DBStats)를 go_sql_*{db_name="onbloc"} 로 노출한다.
+func RegisterDBStats(db *sql.DB) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "onbloc"))
+}
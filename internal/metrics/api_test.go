@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(GinMiddleware())
+	r.GET("/blocks/:height", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	t.Run("등록된 라우트 경로와 상태 코드로 집계한다", func(t *testing.T) {
+		before := testutil.CollectAndCount(requestDuration)
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/blocks/10", nil))
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/blocks/11", nil))
+		assert.Equal(t, before+1, testutil.CollectAndCount(requestDuration))
+	})
+
+	t.Run("등록되지 않은 경로는 unmatched 로 묶는다", func(t *testing.T) {
+		before := testutil.CollectAndCount(requestDuration)
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/1", nil))
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/2", nil))
+		assert.Equal(t, before+1, testutil.CollectAndCount(requestDuration))
+	})
+}
+
+func TestIncCache(t *testing.T) {
+	IncCache("balances", CacheHit)
+	IncCache("balances", CacheHit)
+	IncCache("balances", CacheMiss)
+	assert.Equal(t, 2.0, testutil.ToFloat64(cacheRequests.WithLabelValues("balances", CacheHit)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(cacheRequests.WithLabelValues("balances", CacheMiss)))
+}
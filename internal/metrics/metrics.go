@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+const namespace = "onbloc"
+
+// Handler 는 기본 레지스트리의 메트릭을 Prometheus 형식으로 노출한다.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+func statusOf(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+const (
+	ResultProcessed = "processed"
+	ResultFailed    = "failed"
+	ResultDuplicate = "duplicate"
+)
+
+var (
+	receivedMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "processor",
+		Name:      "messages_received_total",
+		Help:      "큐에서 받은 메시지 수",
+	})
+
+	processedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "processor",
+		Name:      "events_total",
+		Help:      "처리 결과별 토큰 이벤트 수",
+	}, []string{"chain", "result"})
+
+	processingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "processor",
+		Name:      "processing_duration_seconds",
+		Help:      "토큰 이벤트 처리 시간",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"chain"})
+
+	queueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "processor",
+		Name:      "queue_depth",
+		Help:      "이벤트 큐에 남은 메시지 수 (근사치)",
+	})
+)
+
+func IncReceivedMessages() {
+	receivedMessages.Inc()
+}
+
+// ObserveEvent 는 이벤트 처리 결과와 start 부터의 처리 시간을 기록한다.
+func ObserveEvent(chainID, result string, start time.Time) {
+	processedEvents.WithLabelValues(chainID, result).Inc()
+	processingDuration.WithLabelValues(chainID).Observe(time.Since(start).Seconds())
+}
+
+func SetQueueDepth(depth int) {
+	queueDepth.Set(float64(depth))
+}
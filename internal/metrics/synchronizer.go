@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"time"
+)
+
+var (
+	headLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "synchronizer",
+		Name:      "head_lag_blocks",
+		Help:      "indexer 의 최신 높이와 저장된 최신 높이의 차이",
+	}, []string{"chain"})
+
+	syncedBlocks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "synchronizer",
+		Name:      "blocks_total",
+		Help:      "저장한 블록 수",
+	}, []string{"chain"})
+
+	syncedTransactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "synchronizer",
+		Name:      "transactions_total",
+		Help:      "저장한 트랜잭션 수",
+	}, []string{"chain"})
+
+	publishedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "synchronizer",
+		Name:      "events_published_total",
+		Help:      "큐에 발행한 토큰 이벤트 수",
+	}, []string{"chain", "status"})
+
+	indexerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "synchronizer",
+		Name:      "indexer_request_duration_seconds",
+		Help:      "tx-indexer 요청 시간",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"chain", "operation"})
+
+	leader = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "synchronizer",
+		Name:      "leader",
+		Help:      "이 인스턴스가 체인의 리더이면 1, 대기 중이면 0",
+	}, []string{"chain"})
+
+	indexerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "synchronizer",
+		Name:      "indexer_errors_total",
+		Help:      "실패한 tx-indexer 요청 수",
+	}, []string{"chain", "operation"})
+)
+
+func SetHeadLag(chainID string, indexerHeight, storedHeight int64) {
+	headLag.WithLabelValues(chainID).Set(float64(indexerHeight - storedHeight))
+}
+
+func AddSyncedBlocks(chainID string, count int) {
+	syncedBlocks.WithLabelValues(chainID).Add(float64(count))
+}
+
+func AddSyncedTransactions(chainID string, count int) {
+	syncedTransactions.WithLabelValues(chainID).Add(float64(count))
+}
+
+func IncPublishedEvents(chainID string, err error) {
+	publishedEvents.WithLabelValues(chainID, statusOf(err)).Inc()
+}
+
+// ObserveIndexerRequest 는 start 부터의 요청 시간을 기록하고, err 가 있으면 오류 수를 올린다.
+func ObserveIndexerRequest(chainID, operation string, start time.Time, err error) {
+	indexerDuration.WithLabelValues(chainID, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		indexerErrors.WithLabelValues(chainID, operation).Inc()
+	}
+}
+
+func SetLeader(chainID string, isLeader bool) {
+	value := 0.0
+	if isLeader {
+		value = 1
+	}
+	leader.WithLabelValues(chainID).Set(value)
+}
@@ -0,0 +1,264 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var files embed.FS
+
+// ErrOutdated 는 데이터베이스 스키마가 바이너리가 요구하는 버전보다 낮을 때 반환된다.
+var ErrOutdated = errors.New("database schema is outdated")
+
+// lockKey 는 여러 인스턴스가 동시에 마이그레이션하지 않도록 잡는 advisory lock 키이다.
+const lockKey = 7_302_114_061
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration 은 migrations/<버전>_<이름>.up.sql 과 .down.sql 한 쌍이다.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// AppliedMigration 은 schema_migrations 에 기록된 적용 내역이다.
+type AppliedMigration struct {
+	Version   int       `gorm:"column:version"`
+	Name      string    `gorm:"column:name"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+// Migrations 는 바이너리에 포함된 마이그레이션을 버전 순으로 반환한다. 버전은 1 부터 빠짐없이 이어져야 한다.
+func Migrations() ([]Migration, error) {
+	return load(files)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		matches := fileName.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("unexpected migration file name: %s", entry.Name())
+		}
+		version, _ := strconv.Atoi(matches[1])
+		data, err := fs.ReadFile(fsys, path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s, %s", version, migration.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("migration versions must start at 1 without gaps: expected %d, got %d", i+1, migration.Version)
+		}
+	}
+	return migrations, nil
+}
+
+// Migrator 는 schema_migrations 에 적용 내역을 기록하며 마이그레이션을 적용하거나 되돌린다.
+// 각 마이그레이션은 advisory lock 을 잡은 트랜잭션 하나에서 실행된다.
+type Migrator struct {
+	db             *gorm.DB
+	migrations     []Migration
+	defaultChainID string
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// WithDefaultChainID 는 chain_id 가 없던 기존 행을 채울 체인을 지정한다. 마이그레이션에서는
+// current_setting('onbloc.default_chain_id', true) 로 읽는다.
+func (m *Migrator) WithDefaultChainID(chainID string) *Migrator {
+	migrator := *m
+	migrator.defaultChainID = chainID
+	return &migrator
+}
+
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Latest 는 바이너리가 요구하는 스키마 버전이다.
+func (m *Migrator) Latest() int {
+	return len(m.migrations)
+}
+
+// Applied 는 적용된 마이그레이션을 버전 순으로 반환한다. schema_migrations 가 없으면 비어 있다.
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	var exists bool
+	if err := m.db.WithContext(ctx).Raw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return nil, fmt.Errorf("failed to check schema_migrations: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	var applied []AppliedMigration
+	err := m.db.WithContext(ctx).
+		Raw("SELECT version, name, applied_at FROM schema_migrations ORDER BY version").
+		Scan(&applied).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// Version 은 적용된 가장 높은 버전이며, 아무것도 적용되지 않았으면 0 이다.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil || len(applied) == 0 {
+		return 0, err
+	}
+	return applied[len(applied)-1].Version, nil
+}
+
+// Check 는 스키마가 Latest 보다 낮으면 ErrOutdated 를 반환한다.
+// 더 높은 버전은 새 바이너리를 배포하는 중일 수 있으므로 허용한다.
+func (m *Migrator) Check(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version < m.Latest() {
+		return fmt.Errorf("%w: version %d, required %d (run `onbloc migrate up`)", ErrOutdated, version, m.Latest())
+	}
+	return nil
+}
+
+// Up 은 target 버전까지 적용하지 않은 마이그레이션을 차례로 적용한다. target 이 0 이면 Latest 까지 적용한다.
+func (m *Migrator) Up(ctx context.Context, target int) ([]Migration, error) {
+	if target == 0 {
+		target = m.Latest()
+	}
+	if target < 0 || target > m.Latest() {
+		return nil, fmt.Errorf("unknown migration version: %d", target)
+	}
+
+	var applied []Migration
+	for _, migration := range m.migrations[:target] {
+		done, err := m.step(ctx, migration, true)
+		if err != nil {
+			return applied, fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if done {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// Down 은 target 보다 높은 버전의 마이그레이션을 높은 버전부터 되돌린다.
+func (m *Migrator) Down(ctx context.Context, target int) ([]Migration, error) {
+	if target < 0 || target > m.Latest() {
+		return nil, fmt.Errorf("unknown migration version: %d", target)
+	}
+
+	var reverted []Migration
+	for i := len(m.migrations) - 1; i >= target; i-- {
+		migration := m.migrations[i]
+		done, err := m.step(ctx, migration, false)
+		if err != nil {
+			return reverted, fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if done {
+			reverted = append(reverted, migration)
+		}
+	}
+	return reverted, nil
+}
+
+// step 은 lock 을 잡은 뒤 적용 여부를 다시 확인하므로, 다른 인스턴스가 먼저 처리한 마이그레이션은 건너뛴다.
+func (m *Migrator) step(ctx context.Context, migration Migration, up bool) (done bool, err error) {
+	err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("SELECT set_config('onbloc.default_chain_id', ?, true)", m.defaultChainID).Error; err != nil {
+			return err
+		}
+		err := tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)`).Error
+		if err != nil {
+			return err
+		}
+
+		var applied bool
+		if err := tx.Raw("SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version = ?)", migration.Version).Scan(&applied).Error; err != nil {
+			return err
+		}
+		if applied == up {
+			return nil
+		}
+
+		if up {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			err = tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name).Error
+		} else {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			err = tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error
+		}
+		done = err == nil
+		return err
+	})
+	return done && err == nil, err
+}
+
+// Check 는 db 의 스키마가 이 바이너리의 마이그레이션 버전보다 낮으면 오류를 반환한다. 서비스 시작 시 호출한다.
+func Check(ctx context.Context, db *gorm.DB) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	return migrator.Check(ctx)
+}
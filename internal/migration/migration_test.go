@@ -0,0 +1,201 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"onbloc/pkg/model"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version)
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := func(content string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(content)} }
+
+	t.Run("버전 순으로 up, down 을 묶는다", func(t *testing.T) {
+		migrations, err := load(fstest.MapFS{
+			"migrations/0002_second.up.sql":   file("up 2"),
+			"migrations/0002_second.down.sql": file("down 2"),
+			"migrations/0001_first.up.sql":    file("up 1"),
+			"migrations/0001_first.down.sql":  file("down 1"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 1, Name: "first", Up: "up 1", Down: "down 1"},
+			{Version: 2, Name: "second", Up: "up 2", Down: "down 2"},
+		}, migrations)
+	})
+
+	t.Run("down 이 없으면 오류", func(t *testing.T) {
+		_, err := load(fstest.MapFS{"migrations/0001_first.up.sql": file("up")})
+		assert.ErrorContains(t, err, "needs both up and down")
+	})
+
+	t.Run("버전이 비어 있으면 오류", func(t *testing.T) {
+		_, err := load(fstest.MapFS{
+			"migrations/0002_second.up.sql":   file("up"),
+			"migrations/0002_second.down.sql": file("down"),
+		})
+		assert.ErrorContains(t, err, "without gaps")
+	})
+
+	t.Run("이름 규칙에 맞지 않는 파일은 오류", func(t *testing.T) {
+		_, err := load(fstest.MapFS{"migrations/first.sql": file("up")})
+		assert.ErrorContains(t, err, "unexpected migration file name")
+	})
+}
+
+// newTestSchema 는 로컬 PostgreSQL 에 빈 스키마를 만들고, 그 스키마를 기본으로 쓰는 연결을 반환한다.
+func newTestSchema(t *testing.T) *gorm.DB {
+	dsn := "host=localhost user=postgres password=password dbname=onbloc port=5432 sslmode=disable"
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	schemaName := fmt.Sprintf("migration_test_%d", time.Now().UnixNano())
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+schemaName).Error)
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schemaName + " CASCADE") })
+
+	db, err := gorm.Open(postgres.Open(dsn+" search_path="+schemaName+",public"), &gorm.Config{})
+	require.NoError(t, err)
+	return db
+}
+
+// TestModelsMatchSchema 는 빈 스키마에 마이그레이션을 적용한 뒤 gorm 모델의 컬럼, 크기, NOT NULL, 인덱스가 있는지 확인한다.
+func TestModelsMatchSchema(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSchema(t)
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Check(ctx))
+
+	models := []any{
+		&model.Block{}, &model.BlockTransaction{}, &model.TokenEvent{}, &model.Balance{},
+		&model.TokenVolume{}, &model.TokenVolumeParticipant{}, &model.SyncCursor{},
+		&model.WebhookSubscription{}, &model.WebhookDelivery{}, &model.WebhookDeliveryAttempt{},
+	}
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(m))
+		table := stmt.Schema.Table
+
+		t.Run(table, func(t *testing.T) {
+			require.True(t, db.Migrator().HasTable(m), "table %s", table)
+			columnTypes, err := db.Migrator().ColumnTypes(m)
+			require.NoError(t, err)
+			columns := make(map[string]gorm.ColumnType, len(columnTypes))
+			for _, columnType := range columnTypes {
+				columns[columnType.Name()] = columnType
+			}
+
+			for _, field := range stmt.Schema.Fields {
+				if field.DBName == "" {
+					continue
+				}
+				column, exists := columns[field.DBName]
+				if !assert.True(t, exists, "column %s.%s", table, field.DBName) {
+					continue
+				}
+				if field.Size > 0 && field.DataType == schema.String {
+					length, ok := column.Length()
+					assert.True(t, ok && length == int64(field.Size), "%s.%s size: model %d, schema %d", table, field.DBName, field.Size, length)
+				}
+				if field.NotNull || field.PrimaryKey {
+					nullable, _ := column.Nullable()
+					assert.False(t, nullable, "%s.%s must be NOT NULL", table, field.DBName)
+				}
+			}
+			for _, index := range stmt.Schema.ParseIndexes() {
+				assert.True(t, db.Migrator().HasIndex(m, index.Name), "index %s on %s", index.Name, table)
+			}
+		})
+	}
+
+	_, err = migrator.Down(ctx, 0)
+	require.NoError(t, err)
+	version, err := migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+}
+
+// TestMigrateFromBaseline 은 최초 schema.sql 로 만들어 데이터가 있는 데이터베이스가 migrate up 으로 현재 스키마가 되는지 확인한다.
+func TestMigrateFromBaseline(t *testing.T) {
+	ctx := context.Background()
+	db := newTestSchema(t)
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	// schema_migrations 없이 schema.sql 만 실행한 상태
+	require.NoError(t, db.Exec(migrations[0].Up).Error)
+	require.NoError(t, db.Exec(`INSERT INTO blocks (hash, height, time) VALUES ('block-1', 1, NOW())`).Error)
+	// 최초 배포의 messages 형태 (필드 이름이 겹치는 Caller, Send, Package 는 저장되지 않았다.)
+	require.NoError(t, db.Exec(`INSERT INTO transactions (index_num, hash, block_height, success, gas_fee, messages, response)
+		VALUES (0, 'tx-1', 1, true, '{}', '[
+			{"Route": "bank", "TypeUrl": "send", "Value": {"FromAddress": "g1from", "ToAddress": "g1to", "Amount": "1ugnot", "PkgPath": "", "Func": "", "Args": null, "Creator": "", "Deposit": ""}},
+			{"Route": "vm", "TypeUrl": "exec", "Value": {"FromAddress": "", "ToAddress": "", "Amount": "", "PkgPath": "gno.land/r/demo/foo", "Func": "Transfer", "Args": ["g1to", "1"], "Creator": "", "Deposit": ""}}
+		]', '{}')`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO token_events (transaction_hash, tx_event_index, pkg_path, type, func, from_addr, to_addr, amount)
+		VALUES ('tx-1', 0, 'gno.land/r/demo/foo', 'Transfer', 'Mint', '', 'g1to', 10)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO balances (address, token_path, amount) VALUES ('g1to', 'gno.land/r/demo/foo', 10)`).Error)
+
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+
+	t.Run("기존 행이 있는데 체인을 지정하지 않으면 적용하지 않는다", func(t *testing.T) {
+		_, err := migrator.Up(ctx, 0)
+		assert.ErrorContains(t, err, "existing rows need a chain id")
+		version, err := migrator.Version(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, version)
+	})
+
+	t.Run("지정한 체인으로 기존 행을 채우고 체인별 제약으로 바꾼다", func(t *testing.T) {
+		_, err := migrator.WithDefaultChainID("dev").Up(ctx, 0)
+		require.NoError(t, err)
+		require.NoError(t, migrator.Check(ctx))
+
+		for _, table := range []string{"blocks", "transactions", "token_events", "balances"} {
+			var chainIDs []string
+			require.NoError(t, db.Raw("SELECT DISTINCT chain_id FROM "+table).Scan(&chainIDs).Error)
+			assert.Equal(t, []string{"dev"}, chainIDs, table)
+		}
+
+		var messages string
+		require.NoError(t, db.Raw("SELECT messages FROM transactions WHERE hash = 'tx-1' AND chain_id = 'dev'").Scan(&messages).Error)
+		assert.JSONEq(t, `[
+			{"route": "bank", "typeUrl": "send", "value": {"from_address": "g1from", "to_address": "g1to", "amount": "1ugnot"}},
+			{"route": "vm", "typeUrl": "exec", "value": {"caller": "", "send": "", "pkg_path": "gno.land/r/demo/foo", "func": "Transfer", "args": ["g1to", "1"]}}
+		]`, messages)
+
+		// 같은 높이, 해시, 이벤트를 다른 체인에 저장할 수 있어야 한다.
+		require.NoError(t, db.Exec(`INSERT INTO blocks (chain_id, hash, height, time) VALUES ('test5', 'block-1', 1, NOW())`).Error)
+		require.NoError(t, db.Exec(`INSERT INTO transactions (chain_id, index_num, hash, block_height, success, gas_fee, messages, response)
+			VALUES ('test5', 0, 'tx-1', 1, true, '{}', '[]', '{}')`).Error)
+		require.NoError(t, db.Exec(`INSERT INTO token_events (chain_id, transaction_hash, tx_event_index, pkg_path, type, func, from_addr, to_addr, amount)
+			VALUES ('test5', 'tx-1', 0, 'gno.land/r/demo/foo', 'Transfer', 'Mint', '', 'g1to', 10)`).Error)
+		require.NoError(t, db.Exec(`INSERT INTO balances (chain_id, address, token_path, amount, trace_parent) VALUES ('test5', 'g1to', 'gno.land/r/demo/foo', 10, '')`).Error)
+
+		// 블록이 없는 체인의 트랜잭션은 외래 키로 거부된다.
+		err = db.Exec(`INSERT INTO transactions (chain_id, index_num, hash, block_height, success, gas_fee, messages, response)
+			VALUES ('other', 0, 'tx-1', 1, true, '{}', '[]', '{}')`).Error
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS balances;
+DROP TABLE IF EXISTS token_events;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS blocks;
@@ -1,3 +1,4 @@
+-- 최초 배포의 schema.sql 과 같은 스키마이다. schema.sql 로 이미 만든 데이터베이스에도 적용할 수 있도록 모든 테이블을 IF NOT EXISTS 로 만든다.
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 CREATE TABLE IF NOT EXISTS blocks (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
@@ -26,7 +27,7 @@ CREATE TABLE IF NOT EXISTS transactions (
     FOREIGN KEY (block_height) REFERENCES blocks(height)
 );
 
-CREATE TABLE token_events (
+CREATE TABLE IF NOT EXISTS token_events (
     id BIGSERIAL PRIMARY KEY,
     transaction_hash VARCHAR(255) NOT NULL,
     tx_event_index INT NOT NULL,
@@ -40,7 +41,7 @@ CREATE TABLE token_events (
     UNIQUE(transaction_hash, tx_event_index)
 );
 
-CREATE TABLE balances (
+CREATE TABLE IF NOT EXISTS balances (
     id SERIAL PRIMARY KEY,
     address VARCHAR(255) NOT NULL,
     token_path VARCHAR(255) NOT NULL,
@@ -48,4 +49,4 @@ CREATE TABLE balances (
     created_at TIMESTAMP DEFAULT NOW(),
     updated_at TIMESTAMP DEFAULT NOW(),
     CONSTRAINT uk_balances_address_token UNIQUE(address, token_path)
-);
\ No newline at end of file
+);
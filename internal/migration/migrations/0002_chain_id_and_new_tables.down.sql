@@ -0,0 +1,30 @@
+-- 여러 체인의 데이터가 있으면 단일 체인 유니크 제약을 만들 수 없어 실패한다.
+DROP TABLE IF EXISTS sync_leases;
+DROP TABLE IF EXISTS sync_cursors;
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TABLE IF EXISTS token_volume_participants;
+DROP TABLE IF EXISTS token_volumes;
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_chain_id_block_height_fkey;
+ALTER TABLE blocks DROP CONSTRAINT IF EXISTS uk_blocks_chain_hash;
+ALTER TABLE blocks DROP CONSTRAINT IF EXISTS uk_blocks_chain_height;
+ALTER TABLE blocks ADD CONSTRAINT blocks_hash_key UNIQUE (hash);
+ALTER TABLE blocks ADD CONSTRAINT blocks_height_key UNIQUE (height);
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS uk_transactions_chain_hash;
+ALTER TABLE transactions ADD CONSTRAINT transactions_hash_key UNIQUE (hash);
+ALTER TABLE transactions ADD CONSTRAINT transactions_block_height_fkey FOREIGN KEY (block_height) REFERENCES blocks(height);
+
+ALTER TABLE token_events DROP CONSTRAINT IF EXISTS token_events_chain_id_transaction_hash_tx_event_index_key;
+ALTER TABLE token_events ADD CONSTRAINT token_events_transaction_hash_tx_event_index_key UNIQUE (transaction_hash, tx_event_index);
+
+ALTER TABLE balances DROP CONSTRAINT IF EXISTS uk_balances_chain_address_token;
+ALTER TABLE balances ADD CONSTRAINT uk_balances_address_token UNIQUE (address, token_path);
+ALTER TABLE balances DROP COLUMN IF EXISTS trace_parent;
+
+ALTER TABLE balances DROP COLUMN IF EXISTS chain_id;
+ALTER TABLE token_events DROP COLUMN IF EXISTS chain_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS chain_id;
+ALTER TABLE blocks DROP COLUMN IF EXISTS chain_id;
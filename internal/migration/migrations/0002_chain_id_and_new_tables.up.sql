@@ -0,0 +1,135 @@
+-- 0001 의 단일 체인 스키마에 chain_id 를 더하고, 이후 추가된 테이블을 만든다.
+-- 기존 행의 chain_id 는 마이그레이션을 실행할 때 지정한 체인(onbloc.default_chain_id)으로 채운다.
+-- 여러 번 실행해도 결과가 같도록 작성해, 중간 단계의 schema.sql 로 만든 데이터베이스에도 적용할 수 있다.
+DO $$
+BEGIN
+    IF COALESCE(current_setting('onbloc.default_chain_id', true), '') = ''
+        AND (EXISTS (SELECT 1 FROM blocks) OR EXISTS (SELECT 1 FROM token_events) OR EXISTS (SELECT 1 FROM balances)) THEN
+        RAISE EXCEPTION 'existing rows need a chain id: run onbloc migrate up --chain <chain id>';
+    END IF;
+END $$;
+
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS chain_id VARCHAR(64);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS chain_id VARCHAR(64);
+ALTER TABLE token_events ADD COLUMN IF NOT EXISTS chain_id VARCHAR(64);
+ALTER TABLE balances ADD COLUMN IF NOT EXISTS chain_id VARCHAR(64);
+ALTER TABLE balances ADD COLUMN IF NOT EXISTS trace_parent VARCHAR(55);
+
+UPDATE blocks SET chain_id = current_setting('onbloc.default_chain_id', true) WHERE chain_id IS NULL;
+UPDATE transactions SET chain_id = current_setting('onbloc.default_chain_id', true) WHERE chain_id IS NULL;
+UPDATE token_events SET chain_id = current_setting('onbloc.default_chain_id', true) WHERE chain_id IS NULL;
+UPDATE balances SET chain_id = current_setting('onbloc.default_chain_id', true) WHERE chain_id IS NULL;
+
+ALTER TABLE blocks ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE transactions ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE token_events ALTER COLUMN chain_id SET NOT NULL;
+ALTER TABLE balances ALTER COLUMN chain_id SET NOT NULL;
+
+-- 외래 키가 blocks 의 유니크 제약에 걸려 있으므로 먼저 지우고, 체인별 제약으로 바꾼 뒤 다시 만든다.
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_block_height_fkey;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_chain_id_block_height_fkey;
+
+ALTER TABLE blocks DROP CONSTRAINT IF EXISTS blocks_hash_key;
+ALTER TABLE blocks DROP CONSTRAINT IF EXISTS blocks_height_key;
+ALTER TABLE blocks DROP CONSTRAINT IF EXISTS uk_blocks_chain_hash;
+ALTER TABLE blocks DROP CONSTRAINT IF EXISTS uk_blocks_chain_height;
+ALTER TABLE blocks ADD CONSTRAINT uk_blocks_chain_hash UNIQUE (chain_id, hash);
+ALTER TABLE blocks ADD CONSTRAINT uk_blocks_chain_height UNIQUE (chain_id, height);
+
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_hash_key;
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS uk_transactions_chain_hash;
+ALTER TABLE transactions ADD CONSTRAINT uk_transactions_chain_hash UNIQUE (chain_id, hash);
+ALTER TABLE transactions ADD CONSTRAINT transactions_chain_id_block_height_fkey
+    FOREIGN KEY (chain_id, block_height) REFERENCES blocks(chain_id, height);
+
+ALTER TABLE token_events DROP CONSTRAINT IF EXISTS token_events_transaction_hash_tx_event_index_key;
+ALTER TABLE token_events DROP CONSTRAINT IF EXISTS token_events_chain_id_transaction_hash_tx_event_index_key;
+ALTER TABLE token_events ADD CONSTRAINT token_events_chain_id_transaction_hash_tx_event_index_key
+    UNIQUE (chain_id, transaction_hash, tx_event_index);
+
+ALTER TABLE balances DROP CONSTRAINT IF EXISTS uk_balances_address_token;
+ALTER TABLE balances DROP CONSTRAINT IF EXISTS uk_balances_chain_address_token;
+ALTER TABLE balances ADD CONSTRAINT uk_balances_chain_address_token UNIQUE (chain_id, address, token_path);
+
+CREATE TABLE IF NOT EXISTS token_volumes (
+    chain_id VARCHAR(64) NOT NULL,
+    token_path VARCHAR(255) NOT NULL,
+    bucket_interval VARCHAR(8) NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    transfer_count BIGINT NOT NULL DEFAULT 0,
+    transfer_volume BIGINT NOT NULL DEFAULT 0,
+    mint_count BIGINT NOT NULL DEFAULT 0,
+    mint_volume BIGINT NOT NULL DEFAULT 0,
+    burn_count BIGINT NOT NULL DEFAULT 0,
+    burn_volume BIGINT NOT NULL DEFAULT 0,
+    unique_senders BIGINT NOT NULL DEFAULT 0,
+    unique_receivers BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (chain_id, token_path, bucket_interval, bucket_start)
+);
+
+CREATE TABLE IF NOT EXISTS token_volume_participants (
+    chain_id VARCHAR(64) NOT NULL,
+    token_path VARCHAR(255) NOT NULL,
+    bucket_interval VARCHAR(8) NOT NULL,
+    bucket_start TIMESTAMP NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    address VARCHAR(255) NOT NULL,
+    PRIMARY KEY (chain_id, token_path, bucket_interval, bucket_start, role, address)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    chain_id VARCHAR(64) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    token_path VARCHAR(255) NOT NULL DEFAULT '',
+    event_func VARCHAR(50) NOT NULL DEFAULT '',
+    min_amount BIGINT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    chain_id VARCHAR(64) NOT NULL,
+    transaction_hash VARCHAR(255) NOT NULL,
+    tx_event_index INT NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_status_code INT,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE(subscription_id, chain_id, transaction_hash, tx_event_index)
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    delivery_id BIGINT NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt INT NOT NULL,
+    status_code INT,
+    error TEXT,
+    duration_ms BIGINT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS sync_cursors (
+    chain_id VARCHAR(64) PRIMARY KEY,
+    height BIGINT NOT NULL,
+    fencing_token BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS sync_leases (
+    name VARCHAR(128) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    token BIGINT NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
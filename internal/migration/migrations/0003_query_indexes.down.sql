@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_blocks_time;
+DROP INDEX IF EXISTS idx_token_events_chain_to;
+DROP INDEX IF EXISTS idx_token_events_chain_from;
@@ -0,0 +1,5 @@
+-- 주소별 이벤트/활동 조회(from_addr = ? OR to_addr = ?)와 블록 시간 조회에 쓰는 인덱스이다.
+-- 트랜잭션 안에서 만들므로 만드는 동안 해당 테이블 쓰기가 막힌다.
+CREATE INDEX IF NOT EXISTS idx_token_events_chain_from ON token_events(chain_id, from_addr);
+CREATE INDEX IF NOT EXISTS idx_token_events_chain_to ON token_events(chain_id, to_addr);
+CREATE INDEX IF NOT EXISTS idx_blocks_time ON blocks(time);
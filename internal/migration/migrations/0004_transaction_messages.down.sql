@@ -0,0 +1,2 @@
+-- 다시 바꾼 messages 는 이전 형태로 되돌리지 않는다. (이전 형태는 주소별 조회에 걸리지 않는다.)
+DROP INDEX IF EXISTS idx_transactions_messages;
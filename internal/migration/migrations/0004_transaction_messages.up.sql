@@ -0,0 +1,32 @@
+-- 최초 배포는 messages 를 {"Route","TypeUrl","Value":{"FromAddress",...}} 형태로 저장했다. 이 형태의 행을
+-- 현재 형태({"route","typeUrl","value":{"from_address",...}})로 바꿔 주소별 트랜잭션 조회에 걸리게 한다.
+-- 이전 형태는 필드 이름이 겹치는 caller, send, package 를 저장하지 못했으므로 빈 값으로 두며, onbloc resync 로 다시 가져오면 채워진다.
+UPDATE transactions t SET messages = (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object(
+        'route', e.m->'Route',
+        'typeUrl', e.m->'TypeUrl',
+        'value', CASE e.m->>'TypeUrl'
+            WHEN 'send' THEN jsonb_build_object(
+                'from_address', e.m->'Value'->'FromAddress',
+                'to_address', e.m->'Value'->'ToAddress',
+                'amount', e.m->'Value'->'Amount')
+            WHEN 'exec' THEN jsonb_build_object(
+                'caller', '', 'send', '',
+                'pkg_path', e.m->'Value'->'PkgPath',
+                'func', e.m->'Value'->'Func',
+                'args', e.m->'Value'->'Args')
+            WHEN 'add_package' THEN jsonb_build_object(
+                'creator', e.m->'Value'->'Creator',
+                'deposit', e.m->'Value'->'Deposit',
+                'package', jsonb_build_object('name', '', 'path', '', 'files', NULL))
+            WHEN 'run' THEN jsonb_build_object(
+                'caller', '', 'send', '',
+                'package', jsonb_build_object('name', '', 'path', '', 'files', NULL))
+            ELSE 'null'::jsonb
+        END) ORDER BY e.idx), '[]'::jsonb)
+    FROM jsonb_array_elements(t.messages) WITH ORDINALITY AS e(m, idx)
+)
+WHERE jsonb_path_exists(t.messages, '$[*].TypeUrl');
+
+-- 주소별 트랜잭션/활동 조회는 messages @> '[{"value":{"caller":"g1..."}}]' 포함 조건으로 이 인덱스를 사용한다.
+CREATE INDEX IF NOT EXISTS idx_transactions_messages ON transactions USING GIN (messages jsonb_path_ops);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_balances_chain_token;
@@ -0,0 +1,3 @@
+-- 토큰 라우팅과 토큰 조회(chain_id = ? AND token_path = ?)에 쓰는 인덱스이다.
+-- uk_balances_chain_address_token 은 address 가 token_path 보다 앞에 있어 이 조회에 쓰이지 않는다.
+CREATE INDEX IF NOT EXISTS idx_balances_chain_token ON balances(chain_id, token_path);
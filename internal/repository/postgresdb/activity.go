@@ -0,0 +1,104 @@
+package postgresdb
+
+import (
+	"context"
+	"onbloc/pkg/model"
+	"strings"
+)
+
+// accountActivitySQL 은 토큰 이벤트(source_order 1)와 트랜잭션 메시지(source_order 0)를 하나의 피드로 합친다.
+// 메시지는 주소가 나타나는 트랜잭션만 인덱스로 고른 뒤 펼친다.
+var accountActivitySQL = `
+SELECT * FROM (
+	SELECT
+		CASE
+			WHEN te.func = 'Mint' THEN 'mint'
+			WHEN te.func = 'Burn' THEN 'burn'
+			WHEN te.from_addr = @address THEN 'transfer_out'
+			ELSE 'transfer_in'
+		END AS type,
+		t.block_height,
+		t.index_num AS tx_index,
+		1 AS source_order,
+		te.tx_event_index AS seq,
+		b.time,
+		t.hash AS transaction_hash,
+		t.success,
+		t.gas_used,
+		t.gas_fee,
+		te.pkg_path,
+		'' AS func,
+		te.from_addr,
+		te.to_addr,
+		te.amount,
+		'' AS coins
+	FROM token_events te
+	JOIN transactions t ON t.chain_id = te.chain_id AND t.hash = te.transaction_hash
+	JOIN blocks b ON b.chain_id = t.chain_id AND b.height = t.block_height
+	WHERE te.chain_id = @chain_id AND (te.from_addr = @address OR te.to_addr = @address)
+
+	UNION ALL
+
+	SELECT
+		CASE m.msg->>'typeUrl'
+			WHEN 'exec' THEN 'call'
+			WHEN 'run' THEN 'run'
+			WHEN 'add_package' THEN 'deploy'
+			ELSE CASE WHEN m.msg->'value'->>'from_address' = @address THEN 'send' ELSE 'receive' END
+		END AS type,
+		t.block_height,
+		t.index_num AS tx_index,
+		0 AS source_order,
+		(m.idx - 1)::int AS seq,
+		b.time,
+		t.hash AS transaction_hash,
+		t.success,
+		t.gas_used,
+		t.gas_fee,
+		COALESCE(m.msg->'value'->>'pkg_path', m.msg->'value'->'package'->>'path', '') AS pkg_path,
+		COALESCE(m.msg->'value'->>'func', '') AS func,
+		COALESCE(m.msg->'value'->>'caller', m.msg->'value'->>'creator', m.msg->'value'->>'from_address', '') AS from_addr,
+		COALESCE(m.msg->'value'->>'to_address', '') AS to_addr,
+		0 AS amount,
+		COALESCE(m.msg->'value'->>'send', m.msg->'value'->>'deposit', m.msg->'value'->>'amount', '') AS coins
+	FROM transactions t
+	JOIN blocks b ON b.chain_id = t.chain_id AND b.height = t.block_height
+	CROSS JOIN LATERAL jsonb_array_elements(t.messages) WITH ORDINALITY AS m(msg, idx)
+	WHERE t.chain_id = @chain_id AND ` + accountMessageFilter("t.messages") + ` AND (
+		m.msg->'value'->>'caller' = @address
+		OR m.msg->'value'->>'creator' = @address
+		OR m.msg->'value'->>'from_address' = @address
+		OR m.msg->'value'->>'to_address' = @address
+	)
+) activities`
+
+func (r Repository) GetAccountActivities(ctx context.Context, chainID, address string, types []string, cursor *model.ActivityCursor, limit int) (activities []model.AccountActivity, err error) {
+	args := accountMessageArgs(address)
+	args["chain_id"] = chainID
+	args["limit"] = limit
+
+	var conditions []string
+	if len(types) > 0 {
+		conditions = append(conditions, "type IN @types")
+		args["types"] = types
+	}
+	if cursor != nil {
+		conditions = append(conditions, "(block_height, tx_index, source_order, seq) < (@cursor_height, @cursor_tx_index, @cursor_source_order, @cursor_seq)")
+		args["cursor_height"] = cursor.BlockHeight
+		args["cursor_tx_index"] = cursor.TxIndex
+		args["cursor_source_order"] = cursor.SourceOrder
+		args["cursor_seq"] = cursor.Seq
+	}
+
+	query := accountActivitySQL
+	if len(conditions) > 0 {
+		query += "\nWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += "\nORDER BY block_height DESC, tx_index DESC, source_order DESC, seq DESC\nLIMIT @limit"
+
+	err = r.db.WithContext(ctx).Raw(query, args).Scan(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
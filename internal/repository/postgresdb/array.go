@@ -0,0 +1,24 @@
+package postgresdb
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// textArray 는 gorm 이 슬라이스를 (a, b) 목록으로 펼치지 않도록 Postgres 배열 리터럴로 바인딩한다.
+type textArray []string
+
+func (a textArray) Value() (driver.Value, error) {
+	var builder strings.Builder
+	builder.WriteByte('{')
+	for i, value := range a {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteByte('"')
+		builder.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value))
+		builder.WriteByte('"')
+	}
+	builder.WriteByte('}')
+	return builder.String(), nil
+}
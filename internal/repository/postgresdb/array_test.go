@@ -0,0 +1,20 @@
+package postgresdb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTextArray_Value(t *testing.T) {
+	t.Run("빈 배열", func(t *testing.T) {
+		value, err := textArray{}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "{}", value)
+	})
+
+	t.Run("따옴표와 역슬래시를 이스케이프한다", func(t *testing.T) {
+		value, err := textArray{"g1abc", `a"b`, `c\d`}.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, `{"g1abc","a\"b","c\\d"}`, value)
+	})
+}
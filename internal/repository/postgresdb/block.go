@@ -2,8 +2,10 @@ package postgresdb
 
 import (
 	"context"
+	"errors"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"onbloc/internal/tracing"
 	"onbloc/pkg/model"
 	"time"
 )
@@ -13,13 +15,17 @@ type Repository struct {
 }
 
 func NewRepository(db *gorm.DB) *Repository {
+	registerErrorTranslation(db)
+	registerTracing(db)
 	return &Repository{db: db}
 }
 
-func (r Repository) GetLatestHeight(ctx context.Context) (int64, error) {
+func (r Repository) GetLatestHeight(ctx context.Context, chainID string) (int64, error) {
 	var block model.Block
-	err := r.db.WithContext(ctx).Order("height desc").First(&block).Error
-	if err == gorm.ErrRecordNotFound {
+	err := r.db.WithContext(ctx).
+		Where("chain_id = ?", chainID).
+		Order("height desc").First(&block).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return 0, nil
 	}
 
@@ -36,7 +42,7 @@ func (r Repository) InsertBlockRange(ctx context.Context, blocks []*model.Block)
 	}
 	err := r.db.WithContext(ctx).
 		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "height"}},
+			Columns:   []clause.Column{{Name: "chain_id"}, {Name: "height"}},
 			DoNothing: true,
 		}).CreateInBatches(blocks, len(blocks)).
 		Error
@@ -52,7 +58,14 @@ func (r Repository) InsertTransactions(ctx context.Context, transactions []*mode
 		return nil
 	}
 	err := r.db.WithContext(ctx).
-		CreateInBatches(transactions, len(transactions)).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "chain_id"}, {Name: "hash"}},
+			// 이전 형태로 저장되어 caller 등이 비어 있는 messages 는 다시 가져올 때(resync) 채운다.
+			DoUpdates: clause.AssignmentColumns([]string{"messages"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "transactions.messages IS DISTINCT FROM EXCLUDED.messages"},
+			}},
+		}).CreateInBatches(transactions, len(transactions)).
 		Error
 	if err != nil {
 		return err
@@ -72,22 +85,26 @@ func (r Repository) InsertTokenEvent(ctx context.Context, event model.TokenEvent
 	return r.db.WithContext(ctx).Create(&event).Error
 }
 
-func (r Repository) UpsertBalance(ctx context.Context, tx *gorm.DB, pkgPath, addr string, amount int64) error {
+func (r Repository) UpsertBalance(ctx context.Context, tx *gorm.DB, chainID, pkgPath, addr string, amount int64) error {
 	balance := model.Balance{
-		Address:   addr,
-		TokenPath: pkgPath,
-		Amount:    amount,
-		UpdatedAt: time.Now(),
+		ChainID:     chainID,
+		Address:     addr,
+		TokenPath:   pkgPath,
+		Amount:      amount,
+		UpdatedAt:   time.Now(),
+		TraceParent: tracing.TraceParent(ctx),
 	}
 
 	return tx.WithContext(ctx).Clauses(clause.OnConflict{
 		Columns: []clause.Column{
+			{Name: "chain_id"},
 			{Name: "address"},
 			{Name: "token_path"},
 		},
 		DoUpdates: clause.Assignments(map[string]interface{}{
-			"amount":     gorm.Expr("balances.amount + EXCLUDED.amount"),
-			"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			"amount":       gorm.Expr("balances.amount + EXCLUDED.amount"),
+			"updated_at":   gorm.Expr("EXCLUDED.updated_at"),
+			"trace_parent": gorm.Expr("EXCLUDED.trace_parent"),
 		}),
 	}).Create(&balance).Error
 }
@@ -98,25 +115,40 @@ func (r Repository) UpdateTransferBalances(ctx context.Context, event model.Toke
 			db: tx,
 		}
 
-		if err := txRepo.UpsertBalance(ctx, nil, event.PkgPath, event.From, -event.Amount); err != nil {
+		if err := txRepo.UpsertBalance(ctx, tx, event.ChainID, event.PkgPath, event.From, -event.Amount); err != nil {
 			return err
 		}
 
-		return txRepo.UpsertBalance(ctx, nil, event.PkgPath, event.To, event.Amount)
+		return txRepo.UpsertBalance(ctx, tx, event.ChainID, event.PkgPath, event.To, event.Amount)
 	})
 }
 
-func (r Repository) GetBalancesByAddress(ctx context.Context, addr string) (balances []model.Balance, err error) {
+func (r Repository) GetBalancesByAddress(ctx context.Context, chainID, addr string) (balances []model.Balance, err error) {
 	err = r.db.WithContext(ctx).
-		Where("address = ?", addr).Find(&balances).Error
+		Where("chain_id = ? and address = ?", chainID, addr).Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetBalancesByAddresses 는 여러 주소의 잔액을 한 번의 쿼리로 조회한다. tokenPaths 가 비어 있으면 모든 토큰을 조회한다.
+func (r Repository) GetBalancesByAddresses(ctx context.Context, chainID string, addresses, tokenPaths []string) (balances []model.Balance, err error) {
+	query := r.db.WithContext(ctx).
+		Where("chain_id = ? and address = ANY(?::text[])", chainID, textArray(addresses))
+	if len(tokenPaths) > 0 {
+		query = query.Where("token_path = ANY(?::text[])", textArray(tokenPaths))
+	}
+	err = query.Order("address, token_path").Find(&balances).Error
 	if err != nil {
 		return nil, err
 	}
 	return
 }
 
-func (r Repository) GetAllBalances(ctx context.Context, offset, limit int) (balances []model.Balance, err error) {
+func (r Repository) GetAllBalances(ctx context.Context, chainID string, offset, limit int) (balances []model.Balance, err error) {
 	err = r.db.WithContext(ctx).
+		Where("chain_id = ?", chainID).
 		Offset(offset).Limit(limit).Find(&balances).Error
 	if err != nil {
 		return nil, err
@@ -124,9 +156,9 @@ func (r Repository) GetAllBalances(ctx context.Context, offset, limit int) (bala
 	return
 }
 
-func (r Repository) GetTokenPathBalanceByAddress(ctx context.Context, tokenPath, address string) (balances []model.Balance, err error) {
+func (r Repository) GetTokenPathBalanceByAddress(ctx context.Context, chainID, tokenPath, address string) (balances []model.Balance, err error) {
 	err = r.db.WithContext(ctx).
-		Where("token_path = ? and address = ?", tokenPath, address).
+		Where("chain_id = ? and token_path = ? and address = ?", chainID, tokenPath, address).
 		Find(&balances).Error
 	if err != nil {
 		return nil, err
@@ -134,9 +166,9 @@ func (r Repository) GetTokenPathBalanceByAddress(ctx context.Context, tokenPath,
 	return
 }
 
-func (r Repository) GetAllTokenPathBalances(ctx context.Context, tokenPath string) (balances []model.Balance, err error) {
+func (r Repository) GetAllTokenPathBalances(ctx context.Context, chainID, tokenPath string) (balances []model.Balance, err error) {
 	err = r.db.WithContext(ctx).
-		Where("token_path = ?", tokenPath).
+		Where("chain_id = ? and token_path = ?", chainID, tokenPath).
 		Find(&balances).Error
 	if err != nil {
 		return nil, err
@@ -144,9 +176,9 @@ func (r Repository) GetAllTokenPathBalances(ctx context.Context, tokenPath strin
 	return
 }
 
-func (r Repository) GetTokenTransferHistoryByAddress(ctx context.Context, address string) (tokenEvents []model.TokenEvent, err error) {
+func (r Repository) GetTokenTransferHistoryByAddress(ctx context.Context, chainID, address string) (tokenEvents []model.TokenEvent, err error) {
 	err = r.db.WithContext(ctx).
-		Where("from_addr = ? or to_addr = ?", address, address).
+		Where("chain_id = ? and (from_addr = ? or to_addr = ?)", chainID, address, address).
 		Find(&tokenEvents).Error
 	if err != nil {
 		return nil, err
@@ -154,8 +186,9 @@ func (r Repository) GetTokenTransferHistoryByAddress(ctx context.Context, addres
 	return
 }
 
-func (r Repository) GetTokenTransferHistories(ctx context.Context) (tokenEvents []model.TokenEvent, err error) {
+func (r Repository) GetTokenTransferHistories(ctx context.Context, chainID string) (tokenEvents []model.TokenEvent, err error) {
 	err = r.db.WithContext(ctx).
+		Where("chain_id = ?", chainID).
 		Find(&tokenEvents).Error
 	if err != nil {
 		return nil, err
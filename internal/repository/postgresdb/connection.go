@@ -0,0 +1,122 @@
+package postgresdb
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"onbloc/pkg/model"
+)
+
+// 아래 조회 함수들은 커서 기반 페이지네이션용으로, 호출자가 limit+1 건을 요청해 다음 페이지 여부를 판단한다.
+
+func (r Repository) ListBalances(ctx context.Context, chainID, address, tokenPath string, afterID int64, limit int) (balances []model.Balance, err error) {
+	query := r.db.WithContext(ctx).Where("chain_id = ? and id > ?", chainID, afterID)
+	if address != "" {
+		query = query.Where("address = ?", address)
+	}
+	if tokenPath != "" {
+		query = query.Where("token_path = ?", tokenPath)
+	}
+	err = query.Order("id asc").Limit(limit).Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ListTokenHolders 는 잔액이 0 보다 큰 보유자만 조회한다. (토큰 holders 수와 같은 기준)
+func (r Repository) ListTokenHolders(ctx context.Context, chainID, tokenPath string, afterID int64, limit int) (balances []model.Balance, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ? and token_path = ? and amount > 0 and id > ?", chainID, tokenPath, afterID).
+		Order("id asc").Limit(limit).Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) ListTokenEvents(ctx context.Context, chainID string, filter model.TokenEventFilter, beforeID int64, limit int) (tokenEvents []model.TokenEvent, err error) {
+	query := r.db.WithContext(ctx).Where("chain_id = ?", chainID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if filter.Address != "" {
+		query = query.Where("from_addr = ? or to_addr = ?", filter.Address, filter.Address)
+	}
+	if filter.TokenPath != "" {
+		query = query.Where("pkg_path = ?", filter.TokenPath)
+	}
+	if filter.Func != "" {
+		query = query.Where("func = ?", filter.Func)
+	}
+	err = query.Order("id desc").Limit(limit).Find(&tokenEvents).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) ListTokens(ctx context.Context, chainID, search, afterPath string, limit int) (tokens []model.Token, err error) {
+	query := r.db.WithContext(ctx).
+		Model(&model.Balance{}).
+		Select("token_path, COUNT(*) FILTER (WHERE amount > 0) AS holders").
+		Where("chain_id = ? and token_path > ?", chainID, afterPath)
+	if search != "" {
+		query = query.Where("token_path ILIKE ?", "%"+likeEscaper.Replace(search)+"%")
+	}
+	err = query.Group("token_path").Order("token_path asc").Limit(limit).Scan(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) GetToken(ctx context.Context, chainID, tokenPath string) (model.Token, error) {
+	var tokens []model.Token
+	err := r.db.WithContext(ctx).
+		Model(&model.Balance{}).
+		Select("token_path, COUNT(*) FILTER (WHERE amount > 0) AS holders").
+		Where("chain_id = ? and token_path = ?", chainID, tokenPath).
+		Group("token_path").
+		Scan(&tokens).Error
+	if err != nil {
+		return model.Token{}, err
+	}
+	if len(tokens) == 0 {
+		return model.Token{}, translateError(gorm.ErrRecordNotFound)
+	}
+	return tokens[0], nil
+}
+
+// TokenExists 는 token_path 의 잔액이 하나라도 있는지 idx_balances_chain_token 으로 확인한다.
+func (r Repository) TokenExists(ctx context.Context, chainID, tokenPath string) (exists bool, err error) {
+	err = r.db.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM balances WHERE chain_id = ? AND token_path = ?)", chainID, tokenPath).
+		Scan(&exists).Error
+	return
+}
+
+func (r Repository) ListBlocks(ctx context.Context, chainID string, beforeHeight int64, limit int) (blocks []model.Block, err error) {
+	query := r.db.WithContext(ctx).Where("chain_id = ?", chainID)
+	if beforeHeight > 0 {
+		query = query.Where("height < ?", beforeHeight)
+	}
+	err = query.Order("height desc").Limit(limit).Find(&blocks).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) ListTransactionsByAddress(ctx context.Context, chainID, address string, beforeID int64, limit int) (transactions []model.BlockTransaction, err error) {
+	query := r.db.WithContext(ctx).
+		Where("chain_id = ?", chainID).
+		Where(accountMessageCondition, accountMessageArgs(address))
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	err = query.Order("id desc").Limit(limit).Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package postgresdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"net"
+	"onbloc/internal/apperror"
+	"strings"
+)
+
+const translateErrorCallback = "onbloc:translate_error"
+
+// registerErrorTranslation 은 모든 쿼리 결과의 오류를 apperror 도메인 오류로 감싼다.
+// 원본 오류도 함께 감싸므로 errors.Is(err, gorm.ErrRecordNotFound) 는 그대로 동작한다.
+func registerErrorTranslation(db *gorm.DB) {
+	callbacks := db.Callback()
+	for _, processor := range []interface {
+		Get(name string) func(*gorm.DB)
+		Register(name string, fn func(*gorm.DB)) error
+	}{callbacks.Query(), callbacks.Row(), callbacks.Raw(), callbacks.Create(), callbacks.Update(), callbacks.Delete()} {
+		if processor.Get(translateErrorCallback) != nil {
+			continue
+		}
+		_ = processor.Register(translateErrorCallback, func(tx *gorm.DB) {
+			if tx.Error != nil {
+				tx.Error = translateError(tx.Error)
+			}
+		})
+	}
+}
+
+func translateError(err error) error {
+	if err == nil || errors.Is(err, apperror.ErrNotFound) || errors.Is(err, apperror.ErrUnavailable) {
+		return err
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%w: %w", apperror.ErrNotFound, err)
+	}
+	if isUnavailable(err) {
+		return fmt.Errorf("%w: %w", apperror.ErrUnavailable, err)
+	}
+	return err
+}
+
+// isUnavailable 은 연결 실패, 타임아웃, 서버 종료처럼 재시도하면 성공할 수 있는 오류인지 판단한다.
+func isUnavailable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 08: connection exception, 57P01~57P03: admin/crash shutdown, cannot connect now
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "57P")
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,39 @@
+package postgresdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"onbloc/internal/apperror"
+	"testing"
+)
+
+func TestTranslateError(t *testing.T) {
+	t.Run("레코드가 없으면 ErrNotFound 로 감싼다", func(t *testing.T) {
+		err := translateError(gorm.ErrRecordNotFound)
+		assert.ErrorIs(t, err, apperror.ErrNotFound)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("연결 오류는 ErrUnavailable 로 감싼다", func(t *testing.T) {
+		assert.ErrorIs(t, translateError(driver.ErrBadConn), apperror.ErrUnavailable)
+		assert.ErrorIs(t, translateError(fmt.Errorf("query: %w", context.DeadlineExceeded)), apperror.ErrUnavailable)
+		assert.ErrorIs(t, translateError(&pgconn.PgError{Code: "57P01"}), apperror.ErrUnavailable)
+	})
+
+	t.Run("그 외 오류는 그대로 반환한다", func(t *testing.T) {
+		err := &pgconn.PgError{Code: "23505"}
+		assert.Equal(t, error(err), translateError(err))
+		assert.Nil(t, translateError(nil))
+	})
+
+	t.Run("이미 감싼 오류는 다시 감싸지 않는다", func(t *testing.T) {
+		err := fmt.Errorf("token: %w", apperror.ErrNotFound)
+		assert.Equal(t, err, translateError(err))
+		assert.False(t, errors.Is(translateError(err), apperror.ErrUnavailable))
+	})
+}
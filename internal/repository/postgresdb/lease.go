@@ -0,0 +1,75 @@
+package postgresdb
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm/clause"
+	"onbloc/pkg/model"
+	"time"
+)
+
+// ErrFenced 는 더 큰 펜싱 토큰을 가진 리더가 이미 커서를 옮겼을 때 반환된다. 이 토큰의 리더는 더 이상 리더가 아니다.
+var ErrFenced = errors.New("stale fencing token")
+
+// 임대(lease)의 만료 판단은 모두 DB 시각(NOW())으로 하므로 인스턴스 간 시계 차이에 영향받지 않는다.
+const (
+	acquireLeaseQuery = `
+INSERT INTO sync_leases (name, holder, token, expires_at)
+VALUES (@name, @holder, 1, NOW() + make_interval(secs => @ttl))
+ON CONFLICT (name) DO UPDATE
+	SET holder = EXCLUDED.holder, token = sync_leases.token + 1, expires_at = EXCLUDED.expires_at
+	WHERE sync_leases.expires_at < NOW()
+RETURNING token`
+
+	renewLeaseQuery = `
+UPDATE sync_leases SET expires_at = NOW() + make_interval(secs => @ttl)
+WHERE name = @name AND holder = @holder AND token = @token AND expires_at >= NOW()`
+
+	releaseLeaseQuery = `
+UPDATE sync_leases SET expires_at = NOW() - INTERVAL '1 second'
+WHERE name = @name AND holder = @holder AND token = @token`
+)
+
+// AcquireLease 는 임대가 없거나 만료되었을 때만 holder 에게 넘기고, 넘길 때마다 1 씩 커지는 펜싱 토큰을 반환한다.
+func (r Repository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (token int64, acquired bool, err error) {
+	var tokens []int64
+	err = r.db.WithContext(ctx).
+		Raw(acquireLeaseQuery, map[string]interface{}{"name": name, "holder": holder, "ttl": ttl.Seconds()}).
+		Scan(&tokens).Error
+	if err != nil || len(tokens) == 0 {
+		return 0, false, err
+	}
+	return tokens[0], true, nil
+}
+
+// RenewLease 는 만료 전에 같은 holder, token 으로만 연장할 수 있다. 연장하지 못했으면 renewed 는 false 이다.
+func (r Repository) RenewLease(ctx context.Context, name, holder string, token int64, ttl time.Duration) (renewed bool, err error) {
+	result := r.db.WithContext(ctx).
+		Exec(renewLeaseQuery, map[string]interface{}{"name": name, "holder": holder, "token": token, "ttl": ttl.Seconds()})
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReleaseLease 는 임대를 바로 만료시켜 대기 중인 인스턴스가 다음 시도에서 가져가게 한다.
+func (r Repository) ReleaseLease(ctx context.Context, name, holder string, token int64) error {
+	return r.db.WithContext(ctx).
+		Exec(releaseLeaseQuery, map[string]interface{}{"name": name, "holder": holder, "token": token}).Error
+}
+
+// AdvanceSyncCursor 는 저장된 펜싱 토큰보다 작지 않은 토큰으로만 커서를 옮긴다. 거부되면 ErrFenced 를 반환한다.
+func (r Repository) AdvanceSyncCursor(ctx context.Context, chainID string, height, fencingToken int64) error {
+	cursor := model.SyncCursor{ChainID: chainID, Height: height, FencingToken: fencingToken, UpdatedAt: time.Now()}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"height", "fencing_token", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "sync_cursors.fencing_token <= EXCLUDED.fencing_token"},
+		}},
+	}).Create(&cursor)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFenced
+	}
+	return nil
+}
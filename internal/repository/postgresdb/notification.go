@@ -0,0 +1,27 @@
+package postgresdb
+
+import (
+	"context"
+	"encoding/json"
+	"gorm.io/gorm"
+	"onbloc/pkg/model"
+)
+
+func (r Repository) GetBalancesTx(ctx context.Context, tx *gorm.DB, chainID, tokenPath string, addresses []string) (balances []model.Balance, err error) {
+	err = tx.WithContext(ctx).
+		Where("chain_id = ? and token_path = ? and address in ?", chainID, tokenPath, addresses).
+		Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Notify 는 트랜잭션이 커밋될 때 LISTEN 중인 연결에 전달된다.
+func (r Repository) Notify(ctx context.Context, tx *gorm.DB, channel string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return tx.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", channel, string(data)).Error
+}
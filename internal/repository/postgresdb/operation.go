@@ -0,0 +1,267 @@
+package postgresdb
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"onbloc/pkg/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetSyncCursor 는 저장된 동기화 커서를 조회한다. 커서가 없으면 found 는 false 이다.
+func (r Repository) GetSyncCursor(ctx context.Context, chainID string) (height int64, found bool, err error) {
+	var cursor model.SyncCursor
+	err = r.db.WithContext(ctx).Where("chain_id = ?", chainID).First(&cursor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return cursor.Height, true, nil
+}
+
+func (r Repository) SetSyncCursor(ctx context.Context, chainID string, height int64) error {
+	cursor := model.SyncCursor{ChainID: chainID, Height: height, UpdatedAt: time.Now()}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"height", "updated_at"}),
+	}).Create(&cursor).Error
+}
+
+func (r Repository) GetTransactionsByHeightRange(ctx context.Context, chainID string, fromHeight, toHeight int64) (transactions []model.BlockTransaction, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ? and block_height between ? and ?", chainID, fromHeight, toHeight).
+		Order("block_height asc, index_num asc").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// expectedBalancesCTE 는 token_events 를 처음부터 다시 적용한 잔액(expected)이다.
+// event-processor 와 같이 Mint/Transfer 는 to 에 더하고, Burn/Transfer 는 from 에서 뺀다.
+const expectedBalancesCTE = `
+WITH deltas AS (
+	SELECT pkg_path AS token_path, to_addr AS address, amount FROM token_events
+	WHERE chain_id = @chain AND func IN ('Transfer', 'Mint') AND (@token = '' OR pkg_path = @token)
+	UNION ALL
+	SELECT pkg_path, from_addr, -amount FROM token_events
+	WHERE chain_id = @chain AND func IN ('Transfer', 'Burn') AND (@token = '' OR pkg_path = @token)
+), expected AS (
+	SELECT token_path, address, SUM(amount)::BIGINT AS amount FROM deltas GROUP BY token_path, address
+)`
+
+// balanceMismatchQuery 는 expected 와 balances 를 비교한다.
+const balanceMismatchQuery = expectedBalancesCTE + `, stored AS (
+	SELECT token_path, address, amount FROM balances
+	WHERE chain_id = @chain AND (@token = '' OR token_path = @token)
+)
+SELECT COALESCE(e.token_path, s.token_path) AS token_path,
+	COALESCE(e.address, s.address) AS address,
+	COALESCE(s.amount, 0) AS stored,
+	COALESCE(e.amount, 0) AS expected
+FROM expected e FULL OUTER JOIN stored s ON e.token_path = s.token_path AND e.address = s.address
+WHERE COALESCE(s.amount, 0) <> COALESCE(e.amount, 0)
+ORDER BY 1, 2`
+
+// FindBalanceMismatches 는 tokenPath 가 비어 있으면 체인의 모든 토큰을 비교한다.
+func (r Repository) FindBalanceMismatches(ctx context.Context, chainID, tokenPath string) (mismatches []model.BalanceMismatch, err error) {
+	err = r.db.WithContext(ctx).
+		Raw(balanceMismatchQuery, map[string]interface{}{"chain": chainID, "token": tokenPath}).
+		Scan(&mismatches).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SetBalance 는 잔액을 증감하지 않고 amount 로 덮어쓴다. (reconcile 보정용)
+func (r Repository) SetBalance(ctx context.Context, chainID, tokenPath, address string, amount int64) error {
+	balance := model.Balance{
+		ChainID:   chainID,
+		Address:   address,
+		TokenPath: tokenPath,
+		Amount:    amount,
+		UpdatedAt: time.Now(),
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "address"}, {Name: "token_path"}},
+		DoUpdates: clause.AssignmentColumns([]string{"amount", "updated_at"}),
+	}).Create(&balance).Error
+}
+
+// PurgeToken 은 토큰의 이벤트, 잔액, 거래량 집계를 한 트랜잭션으로 삭제하고 삭제한 이벤트 수를 반환한다.
+func (r Repository) PurgeToken(ctx context.Context, chainID, tokenPath string) (deletedEvents int64, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("chain_id = ? and pkg_path = ?", chainID, tokenPath).Delete(&model.TokenEvent{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deletedEvents = result.RowsAffected
+
+		if err := tx.Where("chain_id = ? and token_path = ?", chainID, tokenPath).Delete(&model.Balance{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("chain_id = ? and token_path = ?", chainID, tokenPath).Delete(&model.TokenVolume{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("chain_id = ? and token_path = ?", chainID, tokenPath).Delete(&model.TokenVolumeParticipant{}).Error
+	})
+	return
+}
+
+// replacedVolumeBucketsQuery 는 교체 전 이벤트와 교체할 이벤트가 속한 (토큰, 간격, 버킷)을 모은다.
+// 버킷 시작은 VolumeAggregator 의 BucketStart 와 같이 블록 시간(UTC)을 간격 단위로 내림한 값이다.
+const replacedVolumeBucketsQuery = `
+CREATE TEMP TABLE replaced_volume_buckets ON COMMIT DROP AS
+WITH events AS (
+	SELECT transaction_hash, pkg_path FROM token_events
+	WHERE chain_id = @chain AND transaction_hash = ANY(@hashes::text[])
+	UNION
+	SELECT * FROM unnest(@event_hashes::text[], @event_tokens::text[])
+)
+SELECT DISTINCT e.pkg_path AS token_path, i.bucket_interval, i.seconds,
+	to_timestamp(floor(extract(epoch FROM b.time) / i.seconds) * i.seconds) AT TIME ZONE 'UTC' AS bucket_start
+FROM events e
+JOIN transactions t ON t.chain_id = @chain AND t.hash = e.transaction_hash
+JOIN blocks b ON b.chain_id = t.chain_id AND b.height = t.block_height
+CROSS JOIN unnest(@intervals::text[], @seconds::text[]::float8[]) AS i(bucket_interval, seconds)`
+
+// rebuildVolumeStatements 는 replaced_volume_buckets 의 거래량 집계를 지우고 교체된 token_events 로 다시 계산한다.
+// event-processor 와 같이 고유 송신자/수신자는 Transfer 만 센다.
+var rebuildVolumeStatements = []string{
+	`DELETE FROM token_volumes v USING replaced_volume_buckets r
+	WHERE v.chain_id = @chain AND v.token_path = r.token_path AND v.bucket_interval = r.bucket_interval AND v.bucket_start = r.bucket_start`,
+	`DELETE FROM token_volume_participants p USING replaced_volume_buckets r
+	WHERE p.chain_id = @chain AND p.token_path = r.token_path AND p.bucket_interval = r.bucket_interval AND p.bucket_start = r.bucket_start`,
+	`CREATE TEMP TABLE replaced_volume_events ON COMMIT DROP AS
+	SELECT r.token_path, r.bucket_interval, r.bucket_start, e.func, e.from_addr, e.to_addr, e.amount
+	FROM replaced_volume_buckets r
+	JOIN token_events e ON e.chain_id = @chain AND e.pkg_path = r.token_path
+	JOIN transactions t ON t.chain_id = e.chain_id AND t.hash = e.transaction_hash
+	JOIN blocks b ON b.chain_id = t.chain_id AND b.height = t.block_height
+	WHERE b.time >= r.bucket_start AND b.time < r.bucket_start + make_interval(secs => r.seconds)`,
+	`INSERT INTO token_volume_participants (chain_id, token_path, bucket_interval, bucket_start, role, address)
+	SELECT DISTINCT @chain, token_path, bucket_interval, bucket_start, 'sender', from_addr FROM replaced_volume_events WHERE func = 'Transfer'
+	UNION
+	SELECT DISTINCT @chain, token_path, bucket_interval, bucket_start, 'receiver', to_addr FROM replaced_volume_events WHERE func = 'Transfer'`,
+	`INSERT INTO token_volumes (chain_id, token_path, bucket_interval, bucket_start,
+		transfer_count, transfer_volume, mint_count, mint_volume, burn_count, burn_volume, unique_senders, unique_receivers, updated_at)
+	SELECT @chain, token_path, bucket_interval, bucket_start,
+		COUNT(*) FILTER (WHERE func = 'Transfer'), COALESCE(SUM(amount) FILTER (WHERE func = 'Transfer'), 0),
+		COUNT(*) FILTER (WHERE func = 'Mint'), COALESCE(SUM(amount) FILTER (WHERE func = 'Mint'), 0),
+		COUNT(*) FILTER (WHERE func = 'Burn'), COALESCE(SUM(amount) FILTER (WHERE func = 'Burn'), 0),
+		COUNT(DISTINCT from_addr) FILTER (WHERE func = 'Transfer'), COUNT(DISTINCT to_addr) FILTER (WHERE func = 'Transfer'), NOW()
+	FROM replaced_volume_events
+	GROUP BY token_path, bucket_interval, bucket_start`,
+}
+
+// ReplaceTokenEvents 는 transactionHashes 에 속한 기존 이벤트를 지우고 다시 추출한 events 로 바꾼다.
+// 바뀐 추출 규칙에서 더 이상 나오지 않는 이벤트도 함께 사라지므로 같은 구간을 여러 번 실행해도 결과가 같다.
+// 교체 전후 이벤트가 속한 버킷의 거래량 집계(token_volumes, token_volume_participants)도 같은 트랜잭션에서 다시 계산한다.
+func (r Repository) ReplaceTokenEvents(ctx context.Context, chainID string, transactionHashes []string, events []model.TokenEvent) error {
+	if len(transactionHashes) == 0 {
+		return nil
+	}
+
+	eventHashes := make(textArray, len(events))
+	eventTokens := make(textArray, len(events))
+	for i, event := range events {
+		eventHashes[i], eventTokens[i] = event.TransactionHash, event.PkgPath
+	}
+	var intervals, seconds textArray
+	for interval, duration := range model.VolumeIntervals {
+		intervals = append(intervals, interval)
+		seconds = append(seconds, strconv.FormatFloat(duration.Seconds(), 'f', -1, 64))
+	}
+	params := map[string]interface{}{
+		"chain":        chainID,
+		"hashes":       textArray(transactionHashes),
+		"event_hashes": eventHashes,
+		"event_tokens": eventTokens,
+		"intervals":    intervals,
+		"seconds":      seconds,
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(replacedVolumeBucketsQuery, params).Error; err != nil {
+			return err
+		}
+		err := tx.Where("chain_id = ? and transaction_hash in ?", chainID, transactionHashes).Delete(&model.TokenEvent{}).Error
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			if err = tx.CreateInBatches(events, len(events)).Error; err != nil {
+				return err
+			}
+		}
+		for _, statement := range rebuildVolumeStatements {
+			if err = tx.Exec(statement, params).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// rebuildBalancesStatements 는 balances 를 본뜬 새 테이블(인덱스, 제약, 기본값 포함)을 채운 뒤 이름을 바꿔 교체한다.
+// 새 테이블의 인덱스와 제약은 자동으로 지은 이름을 가지므로, 같은 정의의 기존 인덱스 이름을 기록해 두었다가 교체 뒤 되돌린다.
+// id 시퀀스는 새 테이블로 넘겨 기존 id 가 유지된다.
+var rebuildBalancesStatements = []string{
+	`LOCK TABLE balances IN EXCLUSIVE MODE`,
+	`DROP TABLE IF EXISTS balances_rebuild`,
+	`CREATE TABLE balances_rebuild (LIKE balances INCLUDING ALL)`,
+	`INSERT INTO balances_rebuild SELECT * FROM balances WHERE chain_id <> @chain`,
+	expectedBalancesCTE + `
+	INSERT INTO balances_rebuild (id, chain_id, address, token_path, amount, created_at, updated_at)
+	SELECT COALESCE(b.id, nextval('balances_id_seq')), @chain, e.address, e.token_path, e.amount, COALESCE(b.created_at, NOW()), NOW()
+	FROM expected e LEFT JOIN balances b ON b.chain_id = @chain AND b.address = e.address AND b.token_path = e.token_path`,
+	`CREATE TEMP TABLE balances_rebuild_index_names ON COMMIT DROP AS
+	SELECT DISTINCT ON (n.indexrelid) nc.relname AS rebuild_name, oc.relname AS name
+	FROM pg_index n
+	JOIN pg_class nc ON nc.oid = n.indexrelid
+	JOIN pg_index o ON o.indrelid = 'balances'::regclass AND o.indisunique = n.indisunique AND o.indisprimary = n.indisprimary
+	JOIN pg_class oc ON oc.oid = o.indexrelid
+	WHERE n.indrelid = 'balances_rebuild'::regclass
+		AND split_part(pg_get_indexdef(n.indexrelid), ' USING ', 2) = split_part(pg_get_indexdef(o.indexrelid), ' USING ', 2)`,
+	`ALTER SEQUENCE balances_id_seq OWNED BY balances_rebuild.id`,
+	`DROP TABLE balances`,
+	`ALTER TABLE balances_rebuild RENAME TO balances`,
+	`DO $$
+	DECLARE
+		r RECORD;
+	BEGIN
+		FOR r IN SELECT rebuild_name, name FROM balances_rebuild_index_names LOOP
+			IF EXISTS (SELECT 1 FROM pg_constraint WHERE conrelid = 'balances'::regclass AND conname = r.rebuild_name) THEN
+				EXECUTE format('ALTER TABLE balances RENAME CONSTRAINT %I TO %I', r.rebuild_name, r.name);
+			ELSE
+				EXECUTE format('ALTER INDEX %I RENAME TO %I', r.rebuild_name, r.name);
+			END IF;
+		END LOOP;
+	END $$`,
+}
+
+// RebuildBalances 는 체인의 잔액을 token_events 로부터 새 테이블에 다시 계산해 한 트랜잭션 안에서 balances 와 교체한다.
+// 교체가 끝날 때까지 balances 쓰기는 막히고 읽기는 기존 테이블로 계속된다. 다른 체인의 잔액은 그대로 옮긴다.
+func (r Repository) RebuildBalances(ctx context.Context, chainID string) error {
+	params := map[string]interface{}{"chain": chainID, "token": ""}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range rebuildBalancesStatements {
+			// 이름 있는 인자가 없는 문장에 인자를 넘기면 gorm 이 SQL 끝에 값을 덧붙인다.
+			var args []interface{}
+			if strings.Contains(statement, "@") {
+				args = append(args, params)
+			}
+			if err := tx.Exec(statement, args...).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
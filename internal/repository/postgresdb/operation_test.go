@@ -0,0 +1,143 @@
+package postgresdb
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"onbloc/internal/migration"
+	"onbloc/pkg/model"
+	"testing"
+	"time"
+)
+
+// newTestRepository 는 로컬 PostgreSQL 에 마이그레이션을 적용한 빈 스키마를 만들어 그 스키마를 쓰는 저장소를 반환한다.
+func newTestRepository(t *testing.T) (*Repository, *gorm.DB) {
+	ctx := context.Background()
+	dsn := "host=localhost user=postgres password=password dbname=onbloc port=5432 sslmode=disable"
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+
+	schemaName := fmt.Sprintf("postgresdb_test_%d", time.Now().UnixNano())
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+schemaName).Error)
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schemaName + " CASCADE") })
+
+	db, err := gorm.Open(postgres.Open(dsn+" search_path="+schemaName+",public"), &gorm.Config{})
+	require.NoError(t, err)
+	migrator, err := migration.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	return NewRepository(db), db
+}
+
+func transferEvent(hash string, index int, token, eventFunc, from, to string, amount int64) model.TokenEvent {
+	return model.TokenEvent{
+		ChainID: "dev", TransactionHash: hash, TxEventIndex: index, Type: "Transfer",
+		PkgPath: token, Func: eventFunc, From: from, To: to, Amount: amount,
+	}
+}
+
+func TestRepository_ReplaceTokenEvents(t *testing.T) {
+	ctx := context.Background()
+	repository, db := newTestRepository(t)
+	require.NoError(t, db.Exec(`INSERT INTO blocks (chain_id, hash, height, time) VALUES
+		('dev', 'block-1', 1, '2024-01-01 10:15:00'), ('dev', 'block-2', 2, '2024-01-01 11:05:00')`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO transactions (chain_id, index_num, hash, block_height, success, gas_fee, messages, response) VALUES
+		('dev', 0, 'tx-1', 1, true, '{}', '[]', '{}'), ('dev', 1, 'tx-2', 1, true, '{}', '[]', '{}'), ('dev', 0, 'tx-3', 2, true, '{}', '[]', '{}')`).Error)
+
+	hashes := []string{"tx-1", "tx-2", "tx-3"}
+	require.NoError(t, repository.ReplaceTokenEvents(ctx, "dev", hashes, []model.TokenEvent{
+		transferEvent("tx-1", 0, "foo", "Transfer", "g1a", "g1b", 10),
+		transferEvent("tx-1", 1, "foo", "Mint", "", "g1a", 5),
+		transferEvent("tx-2", 0, "bar", "Burn", "g1a", "", 3),
+		transferEvent("tx-3", 0, "foo", "Transfer", "g1a", "g1c", 7),
+	}))
+
+	// 추출 규칙이 바뀌어 tx-1 의 Mint 와 tx-2 의 Burn 이 사라지고 Transfer 가 바뀌었다.
+	require.NoError(t, repository.ReplaceTokenEvents(ctx, "dev", []string{"tx-1", "tx-2"}, []model.TokenEvent{
+		transferEvent("tx-1", 0, "foo", "Transfer", "g1a", "g1d", 20),
+	}))
+
+	var events []model.TokenEvent
+	require.NoError(t, db.Order("transaction_hash, tx_event_index").Find(&events).Error)
+	require.Len(t, events, 2)
+	assert.Equal(t, "g1d", events[0].To)
+	assert.Equal(t, "tx-3", events[1].TransactionHash)
+
+	volume := func(token, interval, bucketStart string) model.TokenVolume {
+		var volumes []model.TokenVolume
+		require.NoError(t, db.Where("chain_id = 'dev' and token_path = ? and bucket_interval = ? and bucket_start = ?", token, interval, bucketStart).
+			Find(&volumes).Error)
+		require.Len(t, volumes, 1, "%s %s %s", token, interval, bucketStart)
+		return volumes[0]
+	}
+
+	t.Run("바뀐 이벤트의 버킷을 다시 계산한다", func(t *testing.T) {
+		hour := volume("foo", model.VolumeIntervalHour, "2024-01-01 10:00:00")
+		assert.Equal(t, [4]int64{1, 20, 0, 0}, [4]int64{hour.TransferCount, hour.TransferVolume, hour.MintCount, hour.MintVolume})
+		assert.Equal(t, [2]int64{1, 1}, [2]int64{hour.UniqueSenders, hour.UniqueReceivers})
+
+		day := volume("foo", model.VolumeIntervalDay, "2024-01-01 00:00:00")
+		assert.Equal(t, [4]int64{2, 27, 0, 0}, [4]int64{day.TransferCount, day.TransferVolume, day.MintCount, day.MintVolume})
+		assert.Equal(t, [2]int64{1, 2}, [2]int64{day.UniqueSenders, day.UniqueReceivers})
+
+		var receivers []string
+		require.NoError(t, db.Model(&model.TokenVolumeParticipant{}).
+			Where("token_path = 'foo' and bucket_interval = ? and bucket_start = '2024-01-01 10:00:00' and role = ?", model.VolumeIntervalHour, model.VolumeRoleReceiver).
+			Pluck("address", &receivers).Error)
+		assert.Equal(t, []string{"g1d"}, receivers)
+	})
+
+	t.Run("교체하지 않은 트랜잭션의 버킷은 그대로 둔다", func(t *testing.T) {
+		hour := volume("foo", model.VolumeIntervalHour, "2024-01-01 11:00:00")
+		assert.Equal(t, [2]int64{1, 7}, [2]int64{hour.TransferCount, hour.TransferVolume})
+	})
+
+	t.Run("이벤트가 모두 사라진 버킷은 지운다", func(t *testing.T) {
+		var count int64
+		require.NoError(t, db.Model(&model.TokenVolume{}).Where("token_path = 'bar'").Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
+
+func TestRepository_RebuildBalances(t *testing.T) {
+	ctx := context.Background()
+	repository, db := newTestRepository(t)
+	require.NoError(t, db.Exec(`INSERT INTO token_events (chain_id, transaction_hash, tx_event_index, type, pkg_path, func, from_addr, to_addr, amount) VALUES
+		('dev', 'tx-1', 0, 'Transfer', 'foo', 'Mint', '', 'g1a', 10),
+		('dev', 'tx-2', 0, 'Transfer', 'foo', 'Transfer', 'g1a', 'g1b', 4)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO balances (chain_id, address, token_path, amount) VALUES
+		('dev', 'g1a', 'foo', 100), ('dev', 'g1z', 'foo', 1), ('test5', 'g1a', 'foo', 42)`).Error)
+	// 마이그레이션 뒤에 추가된 인덱스도 교체 후 남아야 한다.
+	require.NoError(t, db.Exec(`CREATE INDEX idx_balances_rebuild_test ON balances (token_path, amount)`).Error)
+
+	var before model.Balance
+	require.NoError(t, db.Where("chain_id = 'dev' and address = 'g1a'").First(&before).Error)
+
+	require.NoError(t, repository.RebuildBalances(ctx, "dev"))
+
+	var balances []model.Balance
+	require.NoError(t, db.Order("chain_id, address").Find(&balances).Error)
+	amounts := make(map[string]int64, len(balances))
+	for _, balance := range balances {
+		amounts[balance.ChainID+"/"+balance.Address] = balance.Amount
+	}
+	assert.Equal(t, map[string]int64{"dev/g1a": 6, "dev/g1b": 4, "test5/g1a": 42}, amounts)
+	assert.Equal(t, before.ID, balances[0].ID)
+
+	for _, index := range []string{"balances_pkey", "uk_balances_chain_address_token", "idx_balances_rebuild_test"} {
+		assert.True(t, db.Migrator().HasIndex(&model.Balance{}, index), index)
+	}
+	assert.True(t, db.Migrator().HasConstraint(&model.Balance{}, "uk_balances_chain_address_token"))
+
+	t.Run("교체 뒤에도 같은 잔액은 중복으로 거부되고 새 id 는 시퀀스에서 이어진다", func(t *testing.T) {
+		assert.Error(t, db.Exec(`INSERT INTO balances (chain_id, address, token_path, amount) VALUES ('dev', 'g1a', 'foo', 1)`).Error)
+		require.NoError(t, repository.SetBalance(ctx, "dev", "foo", "g1new", 1))
+		var created model.Balance
+		require.NoError(t, db.Where("address = 'g1new'").First(&created).Error)
+		assert.Greater(t, created.ID, balances[len(balances)-1].ID)
+	})
+}
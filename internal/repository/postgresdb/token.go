@@ -0,0 +1,48 @@
+package postgresdb
+
+import (
+	"context"
+	"gorm.io/gorm/clause"
+	"onbloc/pkg/model"
+	"strings"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchTokensByPathPrefix 는 token_path 가 prefix 로 시작하는 토큰을 조회한다.
+func (r Repository) SearchTokensByPathPrefix(ctx context.Context, chainID, prefix string, limit int) (tokens []model.Token, err error) {
+	err = r.db.WithContext(ctx).
+		Model(&model.Balance{}).
+		Select("token_path, COUNT(*) FILTER (WHERE amount > 0) AS holders").
+		Where("chain_id = ? and token_path ILIKE ?", chainID, likeEscaper.Replace(prefix)+"%").
+		Group("token_path").
+		Order("token_path").
+		Limit(limit).
+		Scan(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SearchTokensBySymbol 은 token_path 의 마지막 세그먼트(심볼)를 기준으로 일치 > 접두어 > 부분 일치 순으로 조회한다.
+func (r Repository) SearchTokensBySymbol(ctx context.Context, chainID, symbol string, limit int) (tokens []model.Token, err error) {
+	escaped := likeEscaper.Replace(symbol)
+	lastSegment := "substring(token_path from '[^/]+$')"
+	err = r.db.WithContext(ctx).
+		Model(&model.Balance{}).
+		Select("token_path, COUNT(*) FILTER (WHERE amount > 0) AS holders").
+		Where("chain_id = ? and "+lastSegment+" ILIKE ?", chainID, "%"+escaped+"%").
+		Group("token_path").
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "CASE WHEN " + lastSegment + " ILIKE ? THEN 0 WHEN " + lastSegment + " ILIKE ? THEN 1 ELSE 2 END, token_path",
+			Vars:               []interface{}{escaped, escaped + "%"},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Scan(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
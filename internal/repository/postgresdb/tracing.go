@@ -0,0 +1,62 @@
+package postgresdb
+
+import (
+	"errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"onbloc/internal/tracing"
+)
+
+const (
+	traceStartCallback = "onbloc:trace_start"
+	traceEndCallback   = "onbloc:trace_end"
+	traceSpanKey       = "onbloc:span"
+)
+
+// registerTracing 은 모든 쿼리를 호출한 ctx 의 span 하위에 db.{operation} span 으로 기록한다.
+func registerTracing(db *gorm.DB) {
+	callbacks := db.Callback()
+	if callbacks.Query().Get(traceStartCallback) != nil {
+		return
+	}
+	_ = callbacks.Create().Before("gorm:create").Register(traceStartCallback, startSpan("db.create"))
+	_ = callbacks.Create().After("gorm:create").Register(traceEndCallback, endSpan)
+	_ = callbacks.Query().Before("gorm:query").Register(traceStartCallback, startSpan("db.query"))
+	_ = callbacks.Query().After("gorm:query").Register(traceEndCallback, endSpan)
+	_ = callbacks.Update().Before("gorm:update").Register(traceStartCallback, startSpan("db.update"))
+	_ = callbacks.Update().After("gorm:update").Register(traceEndCallback, endSpan)
+	_ = callbacks.Delete().Before("gorm:delete").Register(traceStartCallback, startSpan("db.delete"))
+	_ = callbacks.Delete().After("gorm:delete").Register(traceEndCallback, endSpan)
+	_ = callbacks.Row().Before("gorm:row").Register(traceStartCallback, startSpan("db.row"))
+	_ = callbacks.Row().After("gorm:row").Register(traceEndCallback, endSpan)
+	_ = callbacks.Raw().Before("gorm:raw").Register(traceStartCallback, startSpan("db.raw"))
+	_ = callbacks.Raw().After("gorm:raw").Register(traceEndCallback, endSpan)
+}
+
+func startSpan(name string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		ctx, span := tracing.Start(tx.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attribute.String("db.system", "postgresql"), attribute.String("db.sql.table", tx.Statement.Table)))
+		tx.Statement.Context = ctx
+		tx.InstanceSet(traceSpanKey, span)
+	}
+}
+
+func endSpan(tx *gorm.DB) {
+	value, ok := tx.InstanceGet(traceSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	span.SetAttributes(
+		attribute.String("db.statement", tx.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", tx.Statement.RowsAffected))
+
+	err := tx.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	tracing.End(span, err)
+}
@@ -0,0 +1,98 @@
+package postgresdb
+
+import (
+	"context"
+	"encoding/json"
+	"onbloc/pkg/model"
+	"strings"
+)
+
+func (r Repository) GetBlocks(ctx context.Context, chainID string, offset, limit int) (blocks []model.Block, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ?", chainID).
+		Order("height desc").
+		Offset(offset).Limit(limit).
+		Find(&blocks).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) GetBlockByHeight(ctx context.Context, chainID string, height int64) (block model.Block, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ? and height = ?", chainID, height).
+		First(&block).Error
+	return
+}
+
+func (r Repository) GetTransactionByHash(ctx context.Context, chainID, hash string) (transaction model.BlockTransaction, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ? and hash = ?", chainID, hash).
+		First(&transaction).Error
+	return
+}
+
+func (r Repository) GetTransactionsByHeight(ctx context.Context, chainID string, height int64) (transactions []model.BlockTransaction, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ? and block_height = ?", chainID, height).
+		Order("index_num asc").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// accountMessageKeys 는 주소가 서명자(caller, creator, from_address)이거나 수신자(to_address)로 나타나는 메시지 값의 키이다.
+var accountMessageKeys = []string{"caller", "creator", "from_address", "to_address"}
+
+// accountMessageFilter 는 column 의 messages 중 하나라도 accountMessageKeys 의 값이 @address 인 행을 찾는다.
+// jsonb_array_elements 로 모든 행을 펼치지 않고 idx_transactions_messages(GIN) 를 쓰도록 @> 포함 조건으로 만든다.
+// 인자는 accountMessageArgs 로 만든다.
+func accountMessageFilter(column string) string {
+	conditions := make([]string, len(accountMessageKeys))
+	for i, key := range accountMessageKeys {
+		conditions[i] = column + " @> CAST(@message_" + key + " AS jsonb)"
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+var accountMessageCondition = accountMessageFilter("transactions.messages")
+
+// accountMessageArgs 는 address 와 accountMessageFilter 의 포함 조건 값([{"value":{"caller":address}}] 등)이다.
+func accountMessageArgs(address string) map[string]interface{} {
+	args := map[string]interface{}{"address": address}
+	for _, key := range accountMessageKeys {
+		contained, _ := json.Marshal([]map[string]map[string]string{{"value": {key: address}}})
+		args["message_"+key] = string(contained)
+	}
+	return args
+}
+
+func (r Repository) GetTransactionsByAddress(ctx context.Context, chainID, address string, offset, limit int) (transactions []model.BlockTransaction, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ?", chainID).
+		Where(accountMessageCondition, accountMessageArgs(address)).
+		Order("block_height desc, index_num desc").
+		Offset(offset).Limit(limit).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) GetTokenEventsByTransactionHashes(ctx context.Context, chainID string, hashes []string) (tokenEvents []model.TokenEvent, err error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ? and transaction_hash in ?", chainID, hashes).
+		Order("transaction_hash, tx_event_index").
+		Find(&tokenEvents).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
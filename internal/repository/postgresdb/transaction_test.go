@@ -0,0 +1,21 @@
+package postgresdb
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAccountMessageFilter(t *testing.T) {
+	t.Run("메시지 값의 키마다 포함 조건을 만든다", func(t *testing.T) {
+		assert.Equal(t, "(t.messages @> CAST(@message_caller AS jsonb) OR t.messages @> CAST(@message_creator AS jsonb)"+
+			" OR t.messages @> CAST(@message_from_address AS jsonb) OR t.messages @> CAST(@message_to_address AS jsonb))",
+			accountMessageFilter("t.messages"))
+	})
+
+	t.Run("인자는 주소를 값으로 가진 메시지 배열이다", func(t *testing.T) {
+		args := accountMessageArgs(`g1"abc`)
+		assert.Equal(t, `g1"abc`, args["address"])
+		assert.JSONEq(t, `[{"value":{"caller":"g1\"abc"}}]`, args["message_caller"].(string))
+		assert.JSONEq(t, `[{"value":{"to_address":"g1\"abc"}}]`, args["message_to_address"].(string))
+	})
+}
@@ -0,0 +1,74 @@
+package postgresdb
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"onbloc/pkg/model"
+	"time"
+)
+
+// GetTransactionBlockTime 은 토큰 이벤트가 속한 트랜잭션의 블록 시간을 조회한다.
+func (r Repository) GetTransactionBlockTime(ctx context.Context, tx *gorm.DB, chainID, txHash string) (time.Time, error) {
+	var blockTime time.Time
+	result := tx.WithContext(ctx).
+		Table("transactions t").
+		Select("b.time").
+		Joins("JOIN blocks b ON b.chain_id = t.chain_id AND b.height = t.block_height").
+		Where("t.chain_id = ? and t.hash = ?", chainID, txHash).
+		Limit(1).
+		Scan(&blockTime)
+	if result.Error != nil {
+		return time.Time{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return time.Time{}, translateError(gorm.ErrRecordNotFound)
+	}
+	return blockTime, nil
+}
+
+func (r Repository) UpsertTokenVolume(ctx context.Context, tx *gorm.DB, volume model.TokenVolume) error {
+	volume.UpdatedAt = time.Now()
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "chain_id"},
+			{Name: "token_path"},
+			{Name: "bucket_interval"},
+			{Name: "bucket_start"},
+		},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"transfer_count":   gorm.Expr("token_volumes.transfer_count + EXCLUDED.transfer_count"),
+			"transfer_volume":  gorm.Expr("token_volumes.transfer_volume + EXCLUDED.transfer_volume"),
+			"mint_count":       gorm.Expr("token_volumes.mint_count + EXCLUDED.mint_count"),
+			"mint_volume":      gorm.Expr("token_volumes.mint_volume + EXCLUDED.mint_volume"),
+			"burn_count":       gorm.Expr("token_volumes.burn_count + EXCLUDED.burn_count"),
+			"burn_volume":      gorm.Expr("token_volumes.burn_volume + EXCLUDED.burn_volume"),
+			"unique_senders":   gorm.Expr("token_volumes.unique_senders + EXCLUDED.unique_senders"),
+			"unique_receivers": gorm.Expr("token_volumes.unique_receivers + EXCLUDED.unique_receivers"),
+			"updated_at":       gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}).Create(&volume).Error
+}
+
+// InsertVolumeParticipant 는 버킷에 처음 등장한 주소일 때만 true 를 반환한다.
+func (r Repository) InsertVolumeParticipant(ctx context.Context, tx *gorm.DB, participant model.TokenVolumeParticipant) (bool, error) {
+	result := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&participant)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r Repository) GetTokenVolumes(ctx context.Context, chainID, tokenPath, interval string, from, to time.Time) (volumes []model.TokenVolume, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ? and token_path = ? and bucket_interval = ?", chainID, tokenPath, interval).
+		Where("bucket_start >= ? and bucket_start < ?", from, to).
+		Order("bucket_start asc").
+		Find(&volumes).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
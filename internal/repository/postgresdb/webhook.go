@@ -0,0 +1,136 @@
+package postgresdb
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"onbloc/pkg/model"
+	"time"
+)
+
+func (r Repository) CreateWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r Repository) GetWebhookSubscriptions(ctx context.Context, chainID string) (subscriptions []model.WebhookSubscription, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ?", chainID).
+		Order("id asc").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) GetWebhookSubscription(ctx context.Context, chainID string, id int64) (subscription model.WebhookSubscription, err error) {
+	err = r.db.WithContext(ctx).
+		Where("chain_id = ? and id = ?", chainID, id).
+		First(&subscription).Error
+	return
+}
+
+func (r Repository) GetWebhookSubscriptionsByIDs(ctx context.Context, ids []int64) (subscriptions []model.WebhookSubscription, err error) {
+	err = r.db.WithContext(ctx).
+		Where("id in ?", ids).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) UpdateWebhookSubscription(ctx context.Context, subscription *model.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Save(subscription).Error
+}
+
+func (r Repository) DeleteWebhookSubscription(ctx context.Context, chainID string, id int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("chain_id = ? and id = ?", chainID, id).
+		Delete(&model.WebhookSubscription{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindMatchingWebhookSubscriptions 는 이벤트 처리 트랜잭션 안에서 이벤트와 일치하는 활성 구독을 조회한다.
+func (r Repository) FindMatchingWebhookSubscriptions(ctx context.Context, tx *gorm.DB, event model.TokenEvent) (subscriptions []model.WebhookSubscription, err error) {
+	err = tx.WithContext(ctx).
+		Where("active and chain_id = ?", event.ChainID).
+		Where("address = '' or address = ? or address = ?", event.From, event.To).
+		Where("token_path = '' or token_path = ?", event.PkgPath).
+		Where("event_func = '' or event_func = ?", event.Func).
+		Where("min_amount <= ?", event.Amount).
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func (r Repository) InsertWebhookDeliveries(ctx context.Context, tx *gorm.DB, deliveries []model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries).Error
+}
+
+// ClaimDueWebhookDeliveries 는 전송 시각이 된 대기 중 전달 건을 잠그고 lease 만큼 next_attempt_at 을 미뤄 다른 디스패처가 가져가지 못하게 한다.
+func (r Repository) ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) (deliveries []model.WebhookDelivery, err error) {
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? and next_attempt_at <= ?", model.WebhookDeliveryPending, time.Now()).
+			Order("next_attempt_at asc").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int64, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+		return tx.Model(&model.WebhookDelivery{}).
+			Where("id in ?", ids).
+			Update("next_attempt_at", time.Now().Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// RecordWebhookAttempt 는 전달 시도 기록과 전달 건 상태 갱신을 함께 저장한다.
+func (r Repository) RecordWebhookAttempt(ctx context.Context, delivery model.WebhookDelivery, attempt model.WebhookDeliveryAttempt) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&attempt).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.WebhookDelivery{}).
+			Where("id = ?", delivery.ID).
+			Updates(map[string]interface{}{
+				"status":           delivery.Status,
+				"attempts":         delivery.Attempts,
+				"last_status_code": delivery.LastStatusCode,
+				"last_error":       delivery.LastError,
+				"next_attempt_at":  delivery.NextAttemptAt,
+				"delivered_at":     delivery.DeliveredAt,
+			}).Error
+	})
+}
+
+func (r Repository) GetWebhookDeliveries(ctx context.Context, subscriptionID int64, offset, limit int) (deliveries []model.WebhookDelivery, err error) {
+	err = r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("id desc").
+		Offset(offset).Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return
+}
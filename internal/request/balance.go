@@ -0,0 +1,6 @@
+package request
+
+type BalanceQueryRequest struct {
+	Addresses  []string `json:"addresses" binding:"required,min=1,max=100,dive,required"`
+	TokenPaths []string `json:"tokenPaths" binding:"max=50,dive,required"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type WebhookSubscriptionRequest struct {
+	URL       string `json:"url" binding:"required,url"`
+	Address   string `json:"address"`
+	TokenPath string `json:"tokenPath"`
+	EventFunc string `json:"eventFunc" binding:"omitempty,oneof=Transfer Mint Burn"`
+	MinAmount int64  `json:"minAmount" binding:"min=0"`
+	Active    *bool  `json:"active"`
+}
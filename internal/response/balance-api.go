@@ -29,3 +29,18 @@ type Transfer struct {
 type TransfersResponse struct {
 	Transfers []Transfer `json:"transfers"`
 }
+
+// BalanceQueryResponse 는 요청한 주소 순서대로 주소별 토큰 잔액을 담는다.
+type BalanceQueryResponse struct {
+	Balances []AddressBalances `json:"balances"`
+}
+
+type AddressBalances struct {
+	Address  string         `json:"address"`
+	Balances []TokenBalance `json:"balances"`
+}
+
+type HoldersResponse struct {
+	Holders    []AccountBalance `json:"holders"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+}
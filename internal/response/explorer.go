@@ -0,0 +1,111 @@
+package response
+
+import "time"
+
+type Block struct {
+	Hash     string    `json:"hash"`
+	Height   int64     `json:"height"`
+	Time     time.Time `json:"time"`
+	NumTxs   int       `json:"numTxs"`
+	TotalTxs int64     `json:"totalTxs"`
+}
+
+type BlocksResponse struct {
+	Blocks []Block `json:"blocks"`
+}
+
+type GasFee struct {
+	Amount int64  `json:"amount"`
+	Denom  string `json:"denom"`
+}
+
+type Transaction struct {
+	Hash        string       `json:"hash"`
+	BlockHeight int64        `json:"blockHeight"`
+	Index       int64        `json:"index"`
+	Success     bool         `json:"success"`
+	GasWanted   int64        `json:"gasWanted"`
+	GasUsed     int64        `json:"gasUsed"`
+	GasFee      GasFee       `json:"gasFee"`
+	Memo        string       `json:"memo"`
+	Messages    []Message    `json:"messages"`
+	TokenEvents []TokenEvent `json:"tokenEvents"`
+}
+
+type TransactionsResponse struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+// Message 는 typeUrl 에 해당하는 필드 하나만 채워진다.
+type Message struct {
+	Route         string         `json:"route"`
+	TypeUrl       string         `json:"typeUrl"`
+	BankMsgSend   *BankMsgSend   `json:"bankMsgSend,omitempty"`
+	MsgCall       *MsgCall       `json:"msgCall,omitempty"`
+	MsgAddPackage *MsgAddPackage `json:"msgAddPackage,omitempty"`
+	MsgRun        *MsgRun        `json:"msgRun,omitempty"`
+}
+
+type BankMsgSend struct {
+	FromAddress string `json:"fromAddress"`
+	ToAddress   string `json:"toAddress"`
+	Amount      string `json:"amount"`
+}
+
+type MsgCall struct {
+	Caller  string   `json:"caller"`
+	Send    string   `json:"send"`
+	PkgPath string   `json:"pkgPath"`
+	Func    string   `json:"func"`
+	Args    []string `json:"args"`
+}
+
+type MsgAddPackage struct {
+	Creator string  `json:"creator"`
+	Deposit string  `json:"deposit"`
+	Package Package `json:"package"`
+}
+
+type MsgRun struct {
+	Caller  string  `json:"caller"`
+	Send    string  `json:"send"`
+	Package Package `json:"package"`
+}
+
+type Package struct {
+	Name  string   `json:"name"`
+	Path  string   `json:"path"`
+	Files []string `json:"files"`
+}
+
+type TokenEvent struct {
+	TransactionHash string `json:"transactionHash"`
+	TxEventIndex    int    `json:"txEventIndex"`
+	Func            string `json:"func"`
+	TokenPath       string `json:"tokenPath"`
+	FromAddress     string `json:"fromAddress"`
+	ToAddress       string `json:"toAddress"`
+	Amount          int64  `json:"amount"`
+}
+
+type Activity struct {
+	Type            string    `json:"type"`
+	Time            time.Time `json:"time"`
+	BlockHeight     int64     `json:"blockHeight"`
+	TransactionHash string    `json:"transactionHash"`
+	Success         bool      `json:"success"`
+	GasUsed         int64     `json:"gasUsed"`
+	GasFee          GasFee    `json:"gasFee"`
+	TokenPath       string    `json:"tokenPath,omitempty"`
+	PkgPath         string    `json:"pkgPath,omitempty"`
+	Func            string    `json:"func,omitempty"`
+	FromAddress     string    `json:"fromAddress,omitempty"`
+	ToAddress       string    `json:"toAddress,omitempty"`
+	Amount          int64     `json:"amount,omitempty"`
+	Coins           string    `json:"coins,omitempty"`
+}
+
+type ActivitiesResponse struct {
+	Activities []Activity `json:"activities"`
+	NextCursor string     `json:"nextCursor,omitempty"`
+}
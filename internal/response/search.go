@@ -0,0 +1,16 @@
+package response
+
+type Token struct {
+	TokenPath string `json:"tokenPath"`
+	Symbol    string `json:"symbol"`
+	Holders   int64  `json:"holders"`
+}
+
+type SearchResponse struct {
+	Query        string           `json:"query"`
+	Kind         string           `json:"kind"`
+	Blocks       []Block          `json:"blocks"`
+	Transactions []Transaction    `json:"transactions"`
+	Tokens       []Token          `json:"tokens"`
+	Balances     []AccountBalance `json:"balances"`
+}
@@ -0,0 +1,23 @@
+package response
+
+const (
+	StreamMessageTransfer = "transfer"
+	StreamMessageBalance  = "balance"
+)
+
+type StreamMessage struct {
+	Type     string          `json:"type"`
+	ChainID  string          `json:"chainId"`
+	Transfer *StreamTransfer `json:"transfer,omitempty"`
+	Balance  *AccountBalance `json:"balance,omitempty"`
+}
+
+type StreamTransfer struct {
+	TransactionHash string `json:"transactionHash"`
+	TxEventIndex    int    `json:"txEventIndex"`
+	Func            string `json:"func"`
+	FromAddress     string `json:"fromAddress"`
+	ToAddress       string `json:"toAddress"`
+	TokenPath       string `json:"tokenPath"`
+	Amount          int64  `json:"amount"`
+}
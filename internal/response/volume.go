@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+type VolumeBucket struct {
+	BucketStart     time.Time `json:"bucketStart"`
+	TransferCount   int64     `json:"transferCount"`
+	TransferVolume  int64     `json:"transferVolume"`
+	UniqueSenders   int64     `json:"uniqueSenders"`
+	UniqueReceivers int64     `json:"uniqueReceivers"`
+	MintCount       int64     `json:"mintCount"`
+	MintVolume      int64     `json:"mintVolume"`
+	BurnCount       int64     `json:"burnCount"`
+	BurnVolume      int64     `json:"burnVolume"`
+}
+
+type VolumeResponse struct {
+	TokenPath string         `json:"tokenPath"`
+	Interval  string         `json:"interval"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Buckets   []VolumeBucket `json:"buckets"`
+}
@@ -0,0 +1,37 @@
+package response
+
+import "time"
+
+type WebhookSubscription struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Address   string    `json:"address"`
+	TokenPath string    `json:"tokenPath"`
+	EventFunc string    `json:"eventFunc"`
+	MinAmount int64     `json:"minAmount"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type WebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscription `json:"subscriptions"`
+}
+
+type WebhookDelivery struct {
+	ID              int64      `json:"id"`
+	TransactionHash string     `json:"transactionHash"`
+	TxEventIndex    int        `json:"txEventIndex"`
+	Status          string     `json:"status"`
+	Attempts        int        `json:"attempts"`
+	LastStatusCode  int        `json:"lastStatusCode"`
+	LastError       string     `json:"lastError"`
+	NextAttemptAt   time.Time  `json:"nextAttemptAt"`
+	DeliveredAt     *time.Time `json:"deliveredAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
@@ -0,0 +1,87 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"onbloc/internal/handler"
+	"onbloc/internal/health"
+	"onbloc/internal/metrics"
+	"onbloc/internal/middleware"
+)
+
+type Handlers struct {
+	Balance *handler.BalanceAPIHandler
+	Stream  *handler.StreamHandler
+	GraphQL *handler.GraphQLHandler
+	Docs    *handler.DocsHandler
+	Health  *health.Checker
+	// WebhookAPIKeys 는 /webhooks 라우트에 필요한 X-API-Key 값들이다.
+	WebhookAPIKeys []string
+}
+
+// New 는 balance-api 의 모든 라우트를 등록한다. 체인별 라우트는 /chains/:chainId 접두어로 함께 등록된다.
+func New(h Handlers) *gin.Engine {
+	r := gin.New()
+	// 핸들러가 넘기는 *gin.Context 에서 요청 ctx 의 span 과 취소를 이어받는다.
+	r.ContextWithFallback = true
+	r.Use(gin.Recovery(), middleware.RequestID(), otelgin.Middleware("balance-api"), middleware.Logger(), metrics.GinMiddleware())
+
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+	r.GET("/healthz", gin.WrapF(h.Health.Liveness))
+	r.GET("/readyz", gin.WrapF(h.Health.Readiness))
+	r.GET("/openapi.json", h.Docs.OpenAPI)
+	r.GET("/docs", h.Docs.SwaggerUI)
+
+	r.NoRoute(handler.NotFound)
+
+	tokens := newTokenRouter(h)
+	for _, group := range []*gin.RouterGroup{&r.RouterGroup, r.Group("/chains/:chainId")} {
+		group.GET("/tokens/*path", tokens.handle)
+		group.POST("/balances/query", h.Balance.QueryBalances)
+		group.GET("/blocks", h.Balance.GetBlocks)
+		group.GET("/blocks/:height", h.Balance.GetBlock)
+		group.GET("/blocks/:height/txs", h.Balance.GetBlockTransactions)
+		group.GET("/txs/*hash", h.Balance.GetTransaction)
+		group.GET("/accounts/:address/txs", h.Balance.GetAccountTransactions)
+		group.GET("/accounts/:address/activity", h.Balance.GetAccountActivities)
+		group.GET("/search", h.Balance.Search)
+		group.GET("/stream/ws", h.Stream.WebSocket)
+		group.GET("/stream/sse", h.Stream.ServerSentEvents)
+		webhooks := group.Group("/webhooks", handler.RequireAPIKey(h.WebhookAPIKeys))
+		webhooks.POST("", h.Balance.CreateWebhookSubscription)
+		webhooks.GET("", h.Balance.GetWebhookSubscriptions)
+		webhooks.GET("/:id", h.Balance.GetWebhookSubscription)
+		webhooks.PUT("/:id", h.Balance.UpdateWebhookSubscription)
+		webhooks.DELETE("/:id", h.Balance.DeleteWebhookSubscription)
+		webhooks.GET("/:id/deliveries", h.Balance.GetWebhookDeliveries)
+		group.GET("/graphql", h.GraphQL.Query)
+		group.POST("/graphql", h.GraphQL.Query)
+	}
+	return r
+}
+
+func newTokenRouter(h Handlers) tokenRouter {
+	return tokenRouter{
+		fixed: map[string]gin.HandlerFunc{
+			"/balances":         h.Balance.GetTokenBalances,
+			"/transfer-history": h.Balance.GetTokenTransferHistory,
+		},
+		actions: []tokenAction{
+			{suffix: "/balances", handler: h.Balance.GetTokenPathBalances},
+			{suffix: "/holders", handler: h.Balance.GetTokenHolders},
+			{suffix: "/volume", handler: h.Balance.GetTokenVolume},
+		},
+		token:   h.Balance.GetToken,
+		noRoute: handler.NotFound,
+		exists:  h.Balance.TokenExists,
+		fail:    handler.WriteError,
+	}
+}
+
+// ResolveTokenRoute 는 /tokens 이후의 경로(ex. /gno.land/r/demo/foo/holders)를 처리할 핸들러와 토큰 경로를 반환한다.
+// 토큰이 있는지는 DB 대신 exists 로 확인한다. 스펙과 라우팅이 맞는지 확인할 때 사용한다.
+func ResolveTokenRoute(h Handlers, path string, exists func(tokenPath string) bool) (gin.HandlerFunc, string, bool) {
+	tokens := newTokenRouter(h)
+	tokens.exists = func(c *gin.Context, tokenPath string) (bool, error) { return exists(tokenPath), nil }
+	return tokens.resolve(nil, path)
+}
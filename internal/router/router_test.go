@@ -0,0 +1,207 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"onbloc/internal/apperror"
+	"onbloc/internal/handler"
+	"onbloc/internal/response"
+	"testing"
+)
+
+func TestTokenRouter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	named := func(name string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"route": name, "tokenPath": c.Param("tokenPath")})
+		}
+	}
+	tokens := tokenRouter{
+		fixed: map[string]gin.HandlerFunc{
+			"/balances":         named("balances"),
+			"/transfer-history": named("transfer-history"),
+		},
+		actions: []tokenAction{
+			{suffix: "/balances", handler: named("token-balances")},
+			{suffix: "/holders", handler: named("holders")},
+			{suffix: "/volume", handler: named("volume")},
+		},
+		token:   named("token"),
+		noRoute: handler.NotFound,
+		exists: func(c *gin.Context, tokenPath string) (bool, error) {
+			switch tokenPath {
+			case "gno.land/r/gnoswap/v1/test_token/bar", "gno.land/r/demo", "gno.land/r/foo/holders", "gno.land/r/foo/volume":
+				return c.Param("chainId") == "", nil
+			}
+			return false, nil
+		},
+		fail: handler.WriteError,
+	}
+	r := gin.New()
+	r.GET("/tokens/*path", tokens.handle)
+	r.GET("/chains/:chainId/tokens/*path", tokens.handle)
+
+	tests := []struct {
+		path      string
+		route     string
+		tokenPath string
+	}{
+		{"/tokens/balances", "balances", ""},
+		{"/tokens/transfer-history", "transfer-history", ""},
+		{"/tokens/gno.land/r/gnoswap/v1/test_token/bar/balances", "token-balances", "gno.land/r/gnoswap/v1/test_token/bar"},
+		{"/tokens/gno.land/r/gnoswap/v1/test_token/bar/holders", "holders", "gno.land/r/gnoswap/v1/test_token/bar"},
+		{"/tokens/gno.land/r/gnoswap/v1/test_token/bar/volume", "volume", "gno.land/r/gnoswap/v1/test_token/bar"},
+		{"/tokens/gno.land/r/gnoswap/v1/test_token/bar", "token", "gno.land/r/gnoswap/v1/test_token/bar"},
+		{"/tokens/gno.land/r/demo/balances/balances", "token-balances", "gno.land/r/demo/balances"},
+		{"/tokens/gno.land/r/demo/balance", "token", "gno.land/r/demo/balance"},
+		{"/tokens/gno.land/r/demo/holders", "holders", "gno.land/r/demo"},
+		{"/tokens/gno.land/r/foo/holders", "token", "gno.land/r/foo/holders"},
+		{"/tokens/gno.land/r/foo/volume", "token", "gno.land/r/foo/volume"},
+		{"/tokens/gno.land/r/foo/holders/holders", "holders", "gno.land/r/foo/holders"},
+		{"/tokens/gno.land/r/unknown/holders", "holders", "gno.land/r/unknown"},
+		{"/chains/test5/tokens/gno.land/r/foo/holders", "holders", "gno.land/r/foo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			var body map[string]string
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.route, body["route"])
+			assert.Equal(t, tt.tokenPath, body["tokenPath"])
+		})
+	}
+
+	for _, path := range []string{"/tokens/", "/tokens/foo", "/tokens/foo/balances", "/tokens/gno.land/r/demo/unknown-action"} {
+		t.Run(path+" 는 404 를 반환한다", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusNotFound, w.Code)
+		})
+	}
+}
+
+func TestTokenRouter_exists(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	named := func(name string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"route": name, "tokenPath": c.Param("tokenPath")})
+		}
+	}
+	newRouter := func(known map[string]bool, err error) (*gin.Engine, *[]string) {
+		var calls []string
+		tokens := tokenRouter{
+			actions: []tokenAction{{suffix: "/balances", handler: named("token-balances")}},
+			token:   named("token"),
+			noRoute: handler.NotFound,
+			exists: func(c *gin.Context, tokenPath string) (bool, error) {
+				calls = append(calls, tokenPath)
+				return known[tokenPath], err
+			},
+			fail: handler.WriteError,
+		}
+		r := gin.New()
+		r.GET("/tokens/*path", tokens.handle)
+		return r, &calls
+	}
+
+	t.Run("일반적인 action 요청은 전체 경로만 한 번 확인한다", func(t *testing.T) {
+		r, calls := newRouter(map[string]bool{"gno.land/r/demo/foo": true}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tokens/gno.land/r/demo/foo/balances", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"route":"token-balances"`)
+		assert.Equal(t, []string{"gno.land/r/demo/foo/balances"}, *calls)
+	})
+
+	t.Run("전체 경로의 토큰이 있을 때만 action 을 뗀 토큰을 확인한다", func(t *testing.T) {
+		r, calls := newRouter(map[string]bool{"gno.land/r/demo/balances": true}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tokens/gno.land/r/demo/balances", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"route":"token"`)
+		assert.Equal(t, []string{"gno.land/r/demo/balances", "gno.land/r/demo"}, *calls)
+	})
+
+	t.Run("조회에 실패하면 없는 토큰으로 보지 않고 오류를 반환한다", func(t *testing.T) {
+		r, calls := newRouter(nil, fmt.Errorf("query: %w", apperror.ErrUnavailable))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tokens/gno.land/r/demo/foo/balances", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "service temporarily unavailable")
+		assert.Len(t, *calls, 1)
+	})
+}
+
+func TestNew_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := New(Handlers{
+		Balance: handler.NewBalanceAPIHandler(nil, "dev"),
+		Stream:  handler.NewStreamHandler(nil, "dev"),
+		GraphQL: handler.NewGraphQLHandler(graphql.Schema{}, "dev"),
+		Docs:    handler.NewDocsHandler(nil),
+	})
+
+	for _, path := range []string{"/unknown", "/chains/dev/unknown", "/tokens/foo", "/chains/dev/tokens/foo/bar"} {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+			var body response.ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Equal(t, "NOT_FOUND", body.Error.Code)
+			assert.NotEmpty(t, body.Error.RequestID)
+		})
+	}
+}
+
+func TestNew_WebhookAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := New(Handlers{
+		Balance:        handler.NewBalanceAPIHandler(nil, "dev"),
+		Stream:         handler.NewStreamHandler(nil, "dev"),
+		GraphQL:        handler.NewGraphQLHandler(graphql.Schema{}, "dev"),
+		Docs:           handler.NewDocsHandler(nil),
+		WebhookAPIKeys: []string{"secret-key"},
+	})
+	serve := func(method, path, key string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		if key != "" {
+			req.Header.Set(handler.APIKeyHeader, key)
+		}
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPost, "/webhooks"},
+		{http.MethodGet, "/webhooks"},
+		{http.MethodGet, "/webhooks/1"},
+		{http.MethodPut, "/chains/dev/webhooks/1"},
+		{http.MethodDelete, "/chains/dev/webhooks/1"},
+		{http.MethodGet, "/chains/dev/webhooks/1/deliveries"},
+	} {
+		t.Run(route.method+" "+route.path+" 는 키가 없으면 401 을 반환한다", func(t *testing.T) {
+			assert.Equal(t, http.StatusUnauthorized, serve(route.method, route.path, ""))
+		})
+	}
+
+	t.Run("키가 맞으면 핸들러까지 전달된다", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/webhooks/abc", "secret-key"))
+	})
+}
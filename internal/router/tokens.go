@@ -0,0 +1,91 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"onbloc/internal/validation"
+	"strings"
+)
+
+// tokenAction 은 /tokens/{path...}/{action} 형태의 하위 라우트이다.
+type tokenAction struct {
+	suffix  string
+	handler gin.HandlerFunc
+}
+
+// tokenRouter 는 슬래시가 포함된 토큰 경로를 처리한다. gin 은 경로 중간의 와일드카드를 지원하지 않아 /tokens/*path 하나로 받은 뒤 여기서 분기한다.
+//
+//   - /tokens/balances, /tokens/transfer-history : 토큰 경로가 없는 고정 라우트 (realm 경로는 항상 도메인으로 시작하므로 충돌하지 않는다)
+//   - /tokens/{path...}/{action} : action 을 제외한 나머지가 유효한 realm 경로일 때
+//   - /tokens/{path...} : 전체가 유효한 realm 경로일 때
+//
+// 어느 것에도 해당하지 않으면 404 를 반환한다. 토큰 경로 자체가 action 이름으로 끝나는 경우(ex. gno.land/r/demo/holders)에는
+// 전체 경로의 토큰이 있고 action 을 뗀 토큰(gno.land/r/demo)이 없을 때만 토큰 조회로 보내고, 그 밖에는 action 이 우선한다.
+// 전체 경로를 먼저 확인하므로 일반적인 action 요청은 exists 를 한 번만 호출한다.
+type tokenRouter struct {
+	fixed   map[string]gin.HandlerFunc
+	actions []tokenAction
+	token   gin.HandlerFunc
+	noRoute gin.HandlerFunc
+	// exists 는 토큰이 있는지 확인한다. nil 이면 항상 action 이 우선한다.
+	exists func(c *gin.Context, tokenPath string) (bool, error)
+	// fail 은 exists 가 실패했을 때 오류 응답을 쓴다.
+	fail func(c *gin.Context, err error)
+}
+
+// resolve 는 /tokens 이후의 경로에 해당하는 핸들러와 토큰 경로를 찾는다.
+func (t tokenRouter) resolve(c *gin.Context, path string) (gin.HandlerFunc, string, bool) {
+	if handler, exists := t.fixed[path]; exists {
+		return handler, "", true
+	}
+
+	fullPath := strings.TrimPrefix(path, "/")
+	_, err := validation.NormalizeRealmPath(fullPath)
+	isRealmPath := err == nil
+
+	for _, action := range t.actions {
+		if !strings.HasSuffix(path, action.suffix) {
+			continue
+		}
+		tokenPath := strings.TrimPrefix(strings.TrimSuffix(path, action.suffix), "/")
+		if _, err := validation.NormalizeRealmPath(tokenPath); err != nil {
+			continue
+		}
+		if isRealmPath && t.exists != nil {
+			isToken, err := t.isFullPathToken(c, fullPath, tokenPath)
+			if err != nil {
+				return func(c *gin.Context) { t.fail(c, err) }, "", true
+			}
+			if isToken {
+				return t.token, fullPath, true
+			}
+		}
+		return action.handler, tokenPath, true
+	}
+
+	if isRealmPath {
+		return t.token, fullPath, true
+	}
+	return nil, "", false
+}
+
+// isFullPathToken 은 action 이름으로 끝나는 전체 경로를 토큰으로 볼지 확인한다. action 을 뗀 토큰은 전체 경로의 토큰이 있을 때만 확인한다.
+func (t tokenRouter) isFullPathToken(c *gin.Context, fullPath, tokenPath string) (bool, error) {
+	exists, err := t.exists(c, fullPath)
+	if err != nil || !exists {
+		return false, err
+	}
+	exists, err = t.exists(c, tokenPath)
+	return !exists, err
+}
+
+func (t tokenRouter) handle(c *gin.Context) {
+	handler, tokenPath, ok := t.resolve(c, c.Param("path"))
+	if !ok {
+		t.noRoute(c)
+		return
+	}
+	if tokenPath != "" {
+		c.Params = append(c.Params, gin.Param{Key: "tokenPath", Value: tokenPath})
+	}
+	handler(c)
+}
@@ -0,0 +1,97 @@
+package balance_api_service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"onbloc/internal/response"
+	tx_indexer "onbloc/internal/tx-indexer"
+	"onbloc/pkg/model"
+	"slices"
+)
+
+func (s Service) GetAccountActivities(ctx context.Context, chainID, address string, types []string, cursor string, limit int) (response.ActivitiesResponse, error) {
+	for _, activityType := range types {
+		if !slices.Contains(model.ActivityTypes, activityType) {
+			return response.ActivitiesResponse{}, fmt.Errorf("unknown activity type %q: %w", activityType, ErrInvalidArgument)
+		}
+	}
+
+	var after *model.ActivityCursor
+	if cursor != "" {
+		decoded, err := DecodeActivityCursor(cursor)
+		if err != nil {
+			return response.ActivitiesResponse{}, err
+		}
+		after = &decoded
+	}
+
+	activities, err := s.repository.GetAccountActivities(ctx, chainID, address, types, after, limit)
+	if err != nil {
+		return response.ActivitiesResponse{}, err
+	}
+
+	resp := response.ActivitiesResponse{
+		Activities: make([]response.Activity, 0, len(activities)),
+	}
+	for _, activity := range activities {
+		resp.Activities = append(resp.Activities, toActivityResponse(activity))
+	}
+	if len(activities) == limit {
+		last := activities[len(activities)-1]
+		resp.NextCursor = EncodeActivityCursor(model.ActivityCursor{
+			BlockHeight: last.BlockHeight,
+			TxIndex:     last.TxIndex,
+			SourceOrder: last.SourceOrder,
+			Seq:         last.Seq,
+		})
+	}
+	return resp, nil
+}
+
+func toActivityResponse(activity model.AccountActivity) response.Activity {
+	var gasFee tx_indexer.GasFee
+	_ = json.Unmarshal(activity.GasFee, &gasFee)
+
+	resp := response.Activity{
+		Type:            activity.Type,
+		Time:            activity.Time,
+		BlockHeight:     activity.BlockHeight,
+		TransactionHash: activity.TransactionHash,
+		Success:         activity.Success,
+		GasUsed:         activity.GasUsed,
+		GasFee:          response.GasFee{Amount: gasFee.Amount, Denom: gasFee.Denom},
+		Func:            activity.Func,
+		FromAddress:     activity.From,
+		ToAddress:       activity.To,
+		Amount:          activity.Amount,
+		Coins:           activity.Coins,
+	}
+	switch activity.Type {
+	case model.ActivityTypeTransferIn, model.ActivityTypeTransferOut, model.ActivityTypeMint, model.ActivityTypeBurn:
+		resp.TokenPath = activity.PkgPath
+	default:
+		resp.PkgPath = activity.PkgPath
+	}
+	return resp
+}
+
+func EncodeActivityCursor(cursor model.ActivityCursor) string {
+	raw := fmt.Sprintf("%d:%d:%d:%d", cursor.BlockHeight, cursor.TxIndex, cursor.SourceOrder, cursor.Seq)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeActivityCursor(cursor string) (model.ActivityCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return model.ActivityCursor{}, fmt.Errorf("invalid cursor: %w", ErrInvalidArgument)
+	}
+
+	var decoded model.ActivityCursor
+	_, err = fmt.Sscanf(string(raw), "%d:%d:%d:%d", &decoded.BlockHeight, &decoded.TxIndex, &decoded.SourceOrder, &decoded.Seq)
+	if err != nil {
+		return model.ActivityCursor{}, fmt.Errorf("invalid cursor: %w", ErrInvalidArgument)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,31 @@
+package balance_api_service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"onbloc/pkg/model"
+	"testing"
+)
+
+func TestActivityCursor(t *testing.T) {
+	t.Run("인코딩한 커서를 그대로 디코딩", func(t *testing.T) {
+		cursor := model.ActivityCursor{BlockHeight: 757, TxIndex: 2, SourceOrder: 1, Seq: 14}
+		decoded, err := DecodeActivityCursor(EncodeActivityCursor(cursor))
+		assert.Nil(t, err)
+		assert.Equal(t, cursor, decoded)
+	})
+
+	t.Run("잘못된 커서는 ErrInvalidArgument", func(t *testing.T) {
+		_, err := DecodeActivityCursor("not a cursor")
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+
+		_, err = DecodeActivityCursor("bm90OmE6Y3Vyc29y")
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+	})
+}
+
+func TestService_GetAccountActivities_UnknownType(t *testing.T) {
+	_, err := Service{}.GetAccountActivities(context.TODO(), "dev", "g1address", []string{"transfer_in", "swap"}, "", 20)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
@@ -0,0 +1,118 @@
+package balance_api_service
+
+import (
+	"context"
+	"fmt"
+	"onbloc/internal/request"
+	"onbloc/internal/response"
+	"onbloc/internal/tracing"
+	"onbloc/pkg/caching"
+	"onbloc/pkg/model"
+	"slices"
+	"strconv"
+)
+
+func (s Service) QueryBalances(ctx context.Context, chainID string, req request.BalanceQueryRequest) (response.BalanceQueryResponse, error) {
+	addresses := uniqueStrings(req.Addresses)
+	tokenPaths := uniqueStrings(req.TokenPaths)
+
+	balances, err := s.repository.GetBalancesByAddresses(ctx, chainID, addresses, tokenPaths)
+	if err != nil {
+		return response.BalanceQueryResponse{}, err
+	}
+	linkBalanceTraces(ctx, balances)
+	return BuildBalanceMatrix(addresses, tokenPaths, balances), nil
+}
+
+// BuildBalanceMatrix 는 tokenPaths 가 주어지면 잔액이 없는 토큰도 0 으로 채워 모든 주소가 같은 열을 갖도록 한다.
+func BuildBalanceMatrix(addresses, tokenPaths []string, balances []model.Balance) response.BalanceQueryResponse {
+	amounts := make(map[string]map[string]int64, len(addresses))
+	for _, balance := range balances {
+		if amounts[balance.Address] == nil {
+			amounts[balance.Address] = make(map[string]int64)
+		}
+		amounts[balance.Address][balance.TokenPath] = balance.Amount
+	}
+
+	resp := response.BalanceQueryResponse{Balances: make([]response.AddressBalances, 0, len(addresses))}
+	for _, address := range addresses {
+		columns := tokenPaths
+		if len(columns) == 0 {
+			for tokenPath := range amounts[address] {
+				columns = append(columns, tokenPath)
+			}
+			slices.Sort(columns)
+		}
+
+		row := response.AddressBalances{Address: address, Balances: make([]response.TokenBalance, 0, len(columns))}
+		for _, tokenPath := range columns {
+			row.Balances = append(row.Balances, response.TokenBalance{
+				TokenPath: tokenPath,
+				Amount:    amounts[address][tokenPath],
+			})
+		}
+		resp.Balances = append(resp.Balances, row)
+	}
+	return resp
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, exists := seen[value]; exists {
+			continue
+		}
+		seen[value] = struct{}{}
+		unique = append(unique, value)
+	}
+	return unique
+}
+
+func (s Service) GetTokenHolders(ctx context.Context, chainID, tokenPath, cursor string, limit int) (response.HoldersResponse, error) {
+	key := fmt.Sprintf("%s:%s:%s:%d", chainID, tokenPath, cursor, limit)
+	return readThrough(ctx, s.cache, "holders", caching.TokenVersionKey(chainID, tokenPath), key,
+		func() (response.HoldersResponse, error) {
+			return s.getTokenHolders(ctx, chainID, tokenPath, cursor, limit)
+		})
+}
+
+func (s Service) getTokenHolders(ctx context.Context, chainID, tokenPath, cursor string, limit int) (response.HoldersResponse, error) {
+	afterID, err := decodeIntCursor(cursor)
+	if err != nil {
+		return response.HoldersResponse{}, err
+	}
+	limit = connectionSize(limit)
+
+	balances, err := s.repository.ListTokenHolders(ctx, chainID, tokenPath, afterID, limit+1)
+	if err != nil {
+		return response.HoldersResponse{}, err
+	}
+	linkBalanceTraces(ctx, balances)
+	connection := newConnection(balances, limit,
+		func(balance model.Balance) string { return strconv.FormatUint(uint64(balance.ID), 10) },
+		toAccountBalanceResponse)
+
+	resp := response.HoldersResponse{Holders: make([]response.AccountBalance, 0, len(connection.Edges))}
+	for _, edge := range connection.Edges {
+		resp.Holders = append(resp.Holders, edge.Node)
+	}
+	if connection.PageInfo.HasNextPage {
+		resp.NextCursor = connection.PageInfo.EndCursor
+	}
+	return resp, nil
+}
+
+// linkBalanceTraces 는 조회 span 에 각 잔액을 마지막으로 갱신한 이벤트 처리 트레이스를 링크한다.
+// 캐시에서 반환한 응답에는 링크가 없다.
+func linkBalanceTraces(ctx context.Context, balances []model.Balance) {
+	traceParents := make([]string, 0, len(balances))
+	for _, balance := range balances {
+		traceParents = append(traceParents, balance.TraceParent)
+	}
+	tracing.LinkTraceParents(ctx, traceParents...)
+}
+
+func toAccountBalanceResponse(balance model.Balance) response.AccountBalance {
+	return response.AccountBalance{Address: balance.Address, TokenPath: balance.TokenPath, Amount: balance.Amount}
+}
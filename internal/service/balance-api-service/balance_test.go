@@ -0,0 +1,37 @@
+package balance_api_service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"onbloc/internal/response"
+	"onbloc/pkg/model"
+	"testing"
+)
+
+func TestBuildBalanceMatrix(t *testing.T) {
+	balances := []model.Balance{
+		{Address: "g1a", TokenPath: "gno.land/r/demo/foo", Amount: 10},
+		{Address: "g1a", TokenPath: "gno.land/r/demo/bar", Amount: 20},
+		{Address: "g1b", TokenPath: "gno.land/r/demo/foo", Amount: 30},
+	}
+
+	t.Run("토큰 경로를 지정하면 없는 잔액은 0 으로 채운다", func(t *testing.T) {
+		resp := BuildBalanceMatrix([]string{"g1b", "g1a", "g1c"}, []string{"gno.land/r/demo/foo", "gno.land/r/demo/bar"}, balances)
+		assert.Equal(t, []response.AddressBalances{
+			{Address: "g1b", Balances: []response.TokenBalance{{TokenPath: "gno.land/r/demo/foo", Amount: 30}, {TokenPath: "gno.land/r/demo/bar", Amount: 0}}},
+			{Address: "g1a", Balances: []response.TokenBalance{{TokenPath: "gno.land/r/demo/foo", Amount: 10}, {TokenPath: "gno.land/r/demo/bar", Amount: 20}}},
+			{Address: "g1c", Balances: []response.TokenBalance{{TokenPath: "gno.land/r/demo/foo", Amount: 0}, {TokenPath: "gno.land/r/demo/bar", Amount: 0}}},
+		}, resp.Balances)
+	})
+
+	t.Run("토큰 경로가 없으면 보유한 토큰만 반환한다", func(t *testing.T) {
+		resp := BuildBalanceMatrix([]string{"g1a", "g1c"}, nil, balances)
+		assert.Equal(t, []response.AddressBalances{
+			{Address: "g1a", Balances: []response.TokenBalance{{TokenPath: "gno.land/r/demo/bar", Amount: 20}, {TokenPath: "gno.land/r/demo/foo", Amount: 10}}},
+			{Address: "g1c", Balances: []response.TokenBalance{}},
+		}, resp.Balances)
+	})
+}
+
+func TestUniqueStrings(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, uniqueStrings([]string{"a", "b", "a"}))
+}
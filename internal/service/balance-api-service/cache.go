@@ -0,0 +1,81 @@
+package balance_api_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"onbloc/internal/logging"
+	"onbloc/internal/metrics"
+	"onbloc/pkg/caching"
+	"time"
+)
+
+const defaultCacheTTL = time.Minute
+
+// responseCache 는 잔액/보유자 응답을 버전 키 기반으로 read-through 캐싱한다.
+// cache 가 nil 이면 항상 저장소를 조회한다.
+type responseCache struct {
+	cache caching.Caching
+	ttl   time.Duration
+}
+
+func newResponseCache(cache caching.Caching, ttl time.Duration) responseCache {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return responseCache{cache: cache, ttl: ttl}
+}
+
+// version 은 버전 키의 현재 값을 반환한다. 아직 갱신된 적 없는 키는 "0" 이다.
+func (r responseCache) version(ctx context.Context, versionKey string) (string, error) {
+	version, err := r.cache.Get(ctx, versionKey)
+	if errors.Is(err, caching.ErrCacheMiss) {
+		return "0", nil
+	}
+	return version, err
+}
+
+// readThrough 는 versionKey 의 현재 버전을 붙인 키로 캐시를 조회하고, 없으면 load 결과를 저장한다.
+// 캐시 오류는 조회를 실패시키지 않고 저장소 조회로 대체한다.
+func readThrough[T any](ctx context.Context, r responseCache, name, versionKey, key string, load func() (T, error)) (T, error) {
+	if r.cache == nil {
+		return load()
+	}
+
+	version, err := r.version(ctx, versionKey)
+	if err != nil {
+		slog.WarnContext(ctx, "cache version lookup failed", "key", versionKey, logging.Err(err))
+		metrics.IncCache(name, metrics.CacheError)
+		return load()
+	}
+	cacheKey := fmt.Sprintf("balance-api:%s:%s:v%s", name, key, version)
+
+	if data, err := r.cache.Get(ctx, cacheKey); err == nil {
+		var value T
+		if err = json.Unmarshal([]byte(data), &value); err == nil {
+			metrics.IncCache(name, metrics.CacheHit)
+			return value, nil
+		}
+		slog.WarnContext(ctx, "cache decode failed", "key", cacheKey, logging.Err(err))
+	} else if !errors.Is(err, caching.ErrCacheMiss) {
+		slog.WarnContext(ctx, "cache lookup failed", "key", cacheKey, logging.Err(err))
+		metrics.IncCache(name, metrics.CacheError)
+	}
+	metrics.IncCache(name, metrics.CacheMiss)
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value, nil
+	}
+	if err = r.cache.SetWithTTL(ctx, cacheKey, data, r.ttl); err != nil {
+		slog.WarnContext(ctx, "cache store failed", "key", cacheKey, logging.Err(err))
+		metrics.IncCache(name, metrics.CacheError)
+	}
+	return value, nil
+}
@@ -0,0 +1,98 @@
+package balance_api_service
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"onbloc/internal/metrics"
+	"onbloc/pkg/caching"
+	"testing"
+	"time"
+)
+
+func TestReadThrough(t *testing.T) {
+	ctx := context.Background()
+	versionKey := caching.AddressVersionKey("dev", "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5")
+
+	t.Run("두 번째 조회는 캐시에서 반환한다", func(t *testing.T) {
+		cache := newResponseCache(caching.NewLRU(10), time.Minute)
+		hits := cacheCount("test_hit", metrics.CacheHit)
+		misses := cacheCount("test_hit", metrics.CacheMiss)
+		loads := 0
+		load := func() (int, error) { loads++; return 42, nil }
+
+		for i := 0; i < 2; i++ {
+			value, err := readThrough(ctx, cache, "test_hit", versionKey, "key", load)
+			require.NoError(t, err)
+			assert.Equal(t, 42, value)
+		}
+		assert.Equal(t, 1, loads)
+		assert.Equal(t, hits+1, cacheCount("test_hit", metrics.CacheHit))
+		assert.Equal(t, misses+1, cacheCount("test_hit", metrics.CacheMiss))
+	})
+
+	t.Run("버전이 오르면 다시 저장소를 조회한다", func(t *testing.T) {
+		lru := caching.NewLRU(10)
+		cache := newResponseCache(lru, time.Minute)
+		loads := 0
+		load := func() (int, error) { loads++; return loads, nil }
+
+		value, err := readThrough(ctx, cache, "test_version", versionKey, "key", load)
+		require.NoError(t, err)
+		assert.Equal(t, 1, value)
+
+		require.NoError(t, lru.IncrBy(ctx, versionKey, 1))
+		value, err = readThrough(ctx, cache, "test_version", versionKey, "key", load)
+		require.NoError(t, err)
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("조회 오류는 캐싱하지 않는다", func(t *testing.T) {
+		cache := newResponseCache(caching.NewLRU(10), time.Minute)
+		loads := 0
+		load := func() (int, error) { loads++; return 0, errors.New("db down") }
+
+		for i := 0; i < 2; i++ {
+			_, err := readThrough(ctx, cache, "test_error", versionKey, "key", load)
+			assert.Error(t, err)
+		}
+		assert.Equal(t, 2, loads)
+	})
+
+	t.Run("캐시 오류는 저장소 조회로 대체하고 오류로 집계한다", func(t *testing.T) {
+		cache := newResponseCache(failingCache{}, time.Minute)
+		errorCount := cacheCount("test_cache_error", metrics.CacheError)
+
+		value, err := readThrough(ctx, cache, "test_cache_error", versionKey, "key", func() (int, error) { return 7, nil })
+		require.NoError(t, err)
+		assert.Equal(t, 7, value)
+		assert.Equal(t, errorCount+1, cacheCount("test_cache_error", metrics.CacheError))
+	})
+
+	t.Run("캐시가 없으면 항상 저장소를 조회한다", func(t *testing.T) {
+		cache := newResponseCache(nil, 0)
+		loads := 0
+		load := func() (int, error) { loads++; return 1, nil }
+
+		for i := 0; i < 2; i++ {
+			_, err := readThrough(ctx, cache, "test_disabled", versionKey, "key", load)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, loads)
+	})
+}
+
+func cacheCount(query, result string) float64 {
+	return testutil.ToFloat64(metrics.CacheRequests(query, result))
+}
+
+// failingCache 는 모든 조회가 실패하는 캐시다.
+type failingCache struct {
+	caching.Caching
+}
+
+func (failingCache) Get(ctx context.Context, key string) (string, error) {
+	return "", errors.New("redis down")
+}
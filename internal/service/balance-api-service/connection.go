@@ -0,0 +1,177 @@
+package balance_api_service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"onbloc/internal/response"
+	"onbloc/pkg/caching"
+	"onbloc/pkg/model"
+	"strconv"
+)
+
+const maxConnectionSize = 100
+
+func EncodeCursor(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func DecodeCursor(cursor string) (string, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("invalid cursor: %w", ErrInvalidArgument)
+	}
+	return string(value), nil
+}
+
+func decodeIntCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	value, err := DecodeCursor(cursor)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %w", ErrInvalidArgument)
+	}
+	return id, nil
+}
+
+func connectionSize(first int) int {
+	if first <= 0 || first > maxConnectionSize {
+		return maxConnectionSize
+	}
+	return first
+}
+
+// newConnection 은 first+1 건으로 조회한 결과에서 다음 페이지 여부를 판단해 Connection 을 만든다.
+func newConnection[M any, T any](items []M, first int, cursorOf func(M) string, convert func(M) T) response.Connection[T] {
+	connection := response.Connection[T]{Edges: make([]response.Edge[T], 0, len(items))}
+	if len(items) > first {
+		items = items[:first]
+		connection.PageInfo.HasNextPage = true
+	}
+	for _, item := range items {
+		connection.Edges = append(connection.Edges, response.Edge[T]{
+			Cursor: EncodeCursor(cursorOf(item)),
+			Node:   convert(item),
+		})
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.EndCursor = connection.Edges[len(connection.Edges)-1].Cursor
+	}
+	return connection
+}
+
+func (s Service) GetBalanceConnection(ctx context.Context, chainID, address, tokenPath string, first int, after string) (response.Connection[response.AccountBalance], error) {
+	afterID, err := decodeIntCursor(after)
+	if err != nil {
+		return response.Connection[response.AccountBalance]{}, err
+	}
+	first = connectionSize(first)
+
+	balances, err := s.repository.ListBalances(ctx, chainID, address, tokenPath, afterID, first+1)
+	if err != nil {
+		return response.Connection[response.AccountBalance]{}, err
+	}
+	return newConnection(balances, first,
+		func(balance model.Balance) string { return strconv.FormatUint(uint64(balance.ID), 10) },
+		toAccountBalanceResponse), nil
+}
+
+func (s Service) GetTokenEventConnection(ctx context.Context, chainID string, filter model.TokenEventFilter, first int, after string) (response.Connection[response.TokenEvent], error) {
+	beforeID, err := decodeIntCursor(after)
+	if err != nil {
+		return response.Connection[response.TokenEvent]{}, err
+	}
+	first = connectionSize(first)
+
+	tokenEvents, err := s.repository.ListTokenEvents(ctx, chainID, filter, beforeID, first+1)
+	if err != nil {
+		return response.Connection[response.TokenEvent]{}, err
+	}
+	return newConnection(tokenEvents, first,
+		func(event model.TokenEvent) string { return strconv.FormatInt(event.ID, 10) },
+		toTokenEventResponse), nil
+}
+
+func (s Service) GetTokenConnection(ctx context.Context, chainID, search string, first int, after string) (response.Connection[response.Token], error) {
+	afterPath := ""
+	if after != "" {
+		var err error
+		if afterPath, err = DecodeCursor(after); err != nil {
+			return response.Connection[response.Token]{}, err
+		}
+	}
+	first = connectionSize(first)
+
+	tokens, err := s.repository.ListTokens(ctx, chainID, search, afterPath, first+1)
+	if err != nil {
+		return response.Connection[response.Token]{}, err
+	}
+	return newConnection(tokens, first,
+		func(token model.Token) string { return token.TokenPath },
+		toTokenResponse), nil
+}
+
+func (s Service) GetToken(ctx context.Context, chainID, tokenPath string) (response.Token, error) {
+	token, err := s.repository.GetToken(ctx, chainID, tokenPath)
+	if errors.Is(err, ErrNotFound) {
+		return response.Token{}, fmt.Errorf("token %s: %w", tokenPath, ErrNotFound)
+	}
+	if err != nil {
+		return response.Token{}, err
+	}
+	return toTokenResponse(token), nil
+}
+
+// TokenExists 는 토큰 라우터가 토큰 경로를 구분할 때 사용한다. 토큰 버전 키로 캐시하므로 잔액이 바뀌면 다시 조회한다.
+func (s Service) TokenExists(ctx context.Context, chainID, tokenPath string) (bool, error) {
+	return readThrough(ctx, s.cache, "token_exists", caching.TokenVersionKey(chainID, tokenPath), chainID+":"+tokenPath,
+		func() (bool, error) {
+			return s.repository.TokenExists(ctx, chainID, tokenPath)
+		})
+}
+
+func (s Service) GetBlockConnection(ctx context.Context, chainID string, first int, after string) (response.Connection[response.Block], error) {
+	beforeHeight, err := decodeIntCursor(after)
+	if err != nil {
+		return response.Connection[response.Block]{}, err
+	}
+	first = connectionSize(first)
+
+	blocks, err := s.repository.ListBlocks(ctx, chainID, beforeHeight, first+1)
+	if err != nil {
+		return response.Connection[response.Block]{}, err
+	}
+	return newConnection(blocks, first,
+		func(block model.Block) string { return strconv.FormatInt(block.Height, 10) },
+		toBlockResponse), nil
+}
+
+func (s Service) GetAccountTransactionConnection(ctx context.Context, chainID, address string, first int, after string) (response.Connection[response.Transaction], error) {
+	beforeID, err := decodeIntCursor(after)
+	if err != nil {
+		return response.Connection[response.Transaction]{}, err
+	}
+	first = connectionSize(first)
+
+	transactions, err := s.repository.ListTransactionsByAddress(ctx, chainID, address, beforeID, first+1)
+	if err != nil {
+		return response.Connection[response.Transaction]{}, err
+	}
+	resp, err := s.toTransactionResponses(ctx, chainID, transactions)
+	if err != nil {
+		return response.Connection[response.Transaction]{}, err
+	}
+	ids := make(map[string]int64, len(transactions))
+	for _, transaction := range transactions {
+		ids[transaction.Hash] = transaction.ID
+	}
+	return newConnection(resp, first,
+		func(transaction response.Transaction) string { return strconv.FormatInt(ids[transaction.Hash], 10) },
+		func(transaction response.Transaction) response.Transaction { return transaction }), nil
+}
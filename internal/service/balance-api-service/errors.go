@@ -0,0 +1,9 @@
+package balance_api_service
+
+import "onbloc/internal/apperror"
+
+var (
+	ErrNotFound        = apperror.ErrNotFound
+	ErrInvalidArgument = apperror.ErrInvalidArgument
+	ErrUnavailable     = apperror.ErrUnavailable
+)
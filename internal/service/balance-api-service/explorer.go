@@ -0,0 +1,220 @@
+package balance_api_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"onbloc/internal/response"
+	tx_indexer "onbloc/internal/tx-indexer"
+	"onbloc/pkg/model"
+)
+
+func (s Service) GetBlocks(ctx context.Context, chainID string, offset, limit int) (response.BlocksResponse, error) {
+	blocks, err := s.repository.GetBlocks(ctx, chainID, offset, limit)
+	if err != nil {
+		return response.BlocksResponse{}, err
+	}
+
+	resp := make([]response.Block, 0, len(blocks))
+	for _, block := range blocks {
+		resp = append(resp, toBlockResponse(block))
+	}
+	return response.BlocksResponse{Blocks: resp}, nil
+}
+
+func (s Service) GetBlock(ctx context.Context, chainID string, height int64) (response.Block, error) {
+	block, err := s.repository.GetBlockByHeight(ctx, chainID, height)
+	if errors.Is(err, ErrNotFound) {
+		return response.Block{}, fmt.Errorf("block %d: %w", height, ErrNotFound)
+	}
+	if err != nil {
+		return response.Block{}, err
+	}
+	return toBlockResponse(block), nil
+}
+
+func (s Service) GetTransaction(ctx context.Context, chainID, hash string) (response.Transaction, error) {
+	transaction, err := s.repository.GetTransactionByHash(ctx, chainID, hash)
+	if errors.Is(err, ErrNotFound) {
+		return response.Transaction{}, fmt.Errorf("transaction %s: %w", hash, ErrNotFound)
+	}
+	if err != nil {
+		return response.Transaction{}, err
+	}
+
+	transactions, err := s.toTransactionResponses(ctx, chainID, []model.BlockTransaction{transaction})
+	if err != nil {
+		return response.Transaction{}, err
+	}
+	return transactions[0], nil
+}
+
+func (s Service) GetBlockTransactions(ctx context.Context, chainID string, height int64) (response.TransactionsResponse, error) {
+	if _, err := s.GetBlock(ctx, chainID, height); err != nil {
+		return response.TransactionsResponse{}, err
+	}
+
+	transactions, err := s.repository.GetTransactionsByHeight(ctx, chainID, height)
+	if err != nil {
+		return response.TransactionsResponse{}, err
+	}
+
+	resp, err := s.toTransactionResponses(ctx, chainID, transactions)
+	if err != nil {
+		return response.TransactionsResponse{}, err
+	}
+	return response.TransactionsResponse{Transactions: resp}, nil
+}
+
+func (s Service) GetAccountTransactions(ctx context.Context, chainID, address string, offset, limit int) (response.TransactionsResponse, error) {
+	transactions, err := s.repository.GetTransactionsByAddress(ctx, chainID, address, offset, limit)
+	if err != nil {
+		return response.TransactionsResponse{}, err
+	}
+
+	resp, err := s.toTransactionResponses(ctx, chainID, transactions)
+	if err != nil {
+		return response.TransactionsResponse{}, err
+	}
+	return response.TransactionsResponse{Transactions: resp}, nil
+}
+
+func (s Service) toTransactionResponses(ctx context.Context, chainID string, transactions []model.BlockTransaction) ([]response.Transaction, error) {
+	hashes := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		hashes = append(hashes, transaction.Hash)
+	}
+
+	tokenEvents, err := s.repository.GetTokenEventsByTransactionHashes(ctx, chainID, hashes)
+	if err != nil {
+		return nil, err
+	}
+	eventsByHash := make(map[string][]response.TokenEvent)
+	for _, event := range tokenEvents {
+		eventsByHash[event.TransactionHash] = append(eventsByHash[event.TransactionHash], toTokenEventResponse(event))
+	}
+
+	resp := make([]response.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		tx, err := toTransactionResponse(transaction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %s: %w", transaction.Hash, err)
+		}
+		tx.TokenEvents = eventsByHash[transaction.Hash]
+		if tx.TokenEvents == nil {
+			tx.TokenEvents = []response.TokenEvent{}
+		}
+		resp = append(resp, tx)
+	}
+	return resp, nil
+}
+
+func toTokenEventResponse(event model.TokenEvent) response.TokenEvent {
+	return response.TokenEvent{
+		TransactionHash: event.TransactionHash,
+		TxEventIndex:    event.TxEventIndex,
+		Func:            event.Func,
+		TokenPath:       event.PkgPath,
+		FromAddress:     event.From,
+		ToAddress:       event.To,
+		Amount:          event.Amount,
+	}
+}
+
+func toBlockResponse(block model.Block) response.Block {
+	return response.Block{
+		Hash:     block.Hash,
+		Height:   block.Height,
+		Time:     block.Time,
+		NumTxs:   block.NumTxs,
+		TotalTxs: block.TotalTxs,
+	}
+}
+
+func toTransactionResponse(transaction model.BlockTransaction) (response.Transaction, error) {
+	var gasFee tx_indexer.GasFee
+	if len(transaction.GasFee) > 0 {
+		if err := json.Unmarshal(transaction.GasFee, &gasFee); err != nil {
+			return response.Transaction{}, err
+		}
+	}
+
+	messages, err := DecodeMessages(transaction.Messages)
+	if err != nil {
+		return response.Transaction{}, err
+	}
+
+	return response.Transaction{
+		Hash:        transaction.Hash,
+		BlockHeight: transaction.BlockHeight,
+		Index:       transaction.IndexNum,
+		Success:     transaction.Success,
+		GasWanted:   transaction.GasWanted,
+		GasUsed:     transaction.GasUsed,
+		GasFee:      response.GasFee{Amount: gasFee.Amount, Denom: gasFee.Denom},
+		Memo:        transaction.Memo,
+		Messages:    messages,
+	}, nil
+}
+
+// DecodeMessages 는 transactions.messages 에 저장된 JSON 을 typeUrl 별 메시지 뷰로 변환한다.
+func DecodeMessages(raw json.RawMessage) ([]response.Message, error) {
+	var messages []tx_indexer.Message
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &messages); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := make([]response.Message, 0, len(messages))
+	for _, message := range messages {
+		view := response.Message{
+			Route:   message.Route,
+			TypeUrl: message.TypeUrl,
+		}
+		value := message.Value
+		switch message.TypeUrl {
+		case tx_indexer.MessageTypeSend:
+			view.BankMsgSend = &response.BankMsgSend{
+				FromAddress: value.BankMsgSend.FromAddress,
+				ToAddress:   value.BankMsgSend.ToAddress,
+				Amount:      value.BankMsgSend.Amount,
+			}
+		case tx_indexer.MessageTypeCall:
+			view.MsgCall = &response.MsgCall{
+				Caller:  value.MsgCall.Caller,
+				Send:    value.MsgCall.Send,
+				PkgPath: value.MsgCall.PkgPath,
+				Func:    value.MsgCall.Func,
+				Args:    value.MsgCall.Args,
+			}
+		case tx_indexer.MessageTypeAddPackage:
+			view.MsgAddPackage = &response.MsgAddPackage{
+				Creator: value.MsgAddPackage.Creator,
+				Deposit: value.MsgAddPackage.Deposit,
+				Package: toPackageResponse(value.MsgAddPackage.Package),
+			}
+		case tx_indexer.MessageTypeRun:
+			view.MsgRun = &response.MsgRun{
+				Caller:  value.MsgRun.Caller,
+				Send:    value.MsgRun.Send,
+				Package: toPackageResponse(value.MsgRun.Package),
+			}
+		}
+		resp = append(resp, view)
+	}
+	return resp, nil
+}
+
+func toPackageResponse(pkg tx_indexer.MemPackage) response.Package {
+	files := make([]string, 0, len(pkg.Files))
+	for _, file := range pkg.Files {
+		files = append(files, file.Name)
+	}
+	return response.Package{
+		Name:  pkg.Name,
+		Path:  pkg.Path,
+		Files: files,
+	}
+}
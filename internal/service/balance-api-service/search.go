@@ -0,0 +1,144 @@
+package balance_api_service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"onbloc/internal/response"
+	"onbloc/pkg/model"
+	"strconv"
+	"strings"
+)
+
+const (
+	SearchKindAddress     = "address"
+	SearchKindHeight      = "height"
+	SearchKindTransaction = "transaction"
+	SearchKindPath        = "path"
+	SearchKindSymbol      = "symbol"
+)
+
+const searchLimit = 20
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// ClassifyQuery 는 검색어를 주소, 블록 높이, 트랜잭션 해시, realm 경로, 토큰 심볼 중 하나로 분류한다.
+func ClassifyQuery(q string) string {
+	switch {
+	case isAddress(q):
+		return SearchKindAddress
+	case isHeight(q):
+		return SearchKindHeight
+	case isTransactionHash(q):
+		return SearchKindTransaction
+	case strings.Contains(q, "/") || strings.HasPrefix(q, "gno.land"):
+		return SearchKindPath
+	default:
+		return SearchKindSymbol
+	}
+}
+
+func isAddress(q string) bool {
+	if len(q) != 40 || !strings.HasPrefix(q, "g1") {
+		return false
+	}
+	for _, r := range q[2:] {
+		if !strings.ContainsRune(bech32Charset, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHeight(q string) bool {
+	height, err := strconv.ParseInt(q, 10, 64)
+	return err == nil && height > 0
+}
+
+func isTransactionHash(q string) bool {
+	hash, err := base64.StdEncoding.DecodeString(q)
+	return err == nil && len(hash) == 32
+}
+
+// TokenSymbol 은 token_path 의 마지막 세그먼트를 심볼로 사용한다.
+func TokenSymbol(tokenPath string) string {
+	return tokenPath[strings.LastIndex(tokenPath, "/")+1:]
+}
+
+func (s Service) Search(ctx context.Context, chainID, q string) (response.SearchResponse, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return response.SearchResponse{}, fmt.Errorf("empty query: %w", ErrInvalidArgument)
+	}
+
+	resp := response.SearchResponse{
+		Query:        q,
+		Kind:         ClassifyQuery(q),
+		Blocks:       []response.Block{},
+		Transactions: []response.Transaction{},
+		Tokens:       []response.Token{},
+		Balances:     []response.AccountBalance{},
+	}
+
+	switch resp.Kind {
+	case SearchKindAddress:
+		balances, err := s.repository.GetBalancesByAddress(ctx, chainID, q)
+		if err != nil {
+			return response.SearchResponse{}, err
+		}
+		for _, balance := range balances {
+			resp.Balances = append(resp.Balances, response.AccountBalance{
+				Address:   balance.Address,
+				TokenPath: balance.TokenPath,
+				Amount:    balance.Amount,
+			})
+		}
+	case SearchKindHeight:
+		height, _ := strconv.ParseInt(q, 10, 64)
+		block, err := s.GetBlock(ctx, chainID, height)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return response.SearchResponse{}, err
+		}
+		if err == nil {
+			resp.Blocks = append(resp.Blocks, block)
+		}
+	case SearchKindTransaction:
+		transaction, err := s.GetTransaction(ctx, chainID, q)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return response.SearchResponse{}, err
+		}
+		if err == nil {
+			resp.Transactions = append(resp.Transactions, transaction)
+		}
+	case SearchKindPath:
+		tokens, err := s.repository.SearchTokensByPathPrefix(ctx, chainID, q, searchLimit)
+		if err != nil {
+			return response.SearchResponse{}, err
+		}
+		resp.Tokens = toTokenResponses(tokens)
+	case SearchKindSymbol:
+		tokens, err := s.repository.SearchTokensBySymbol(ctx, chainID, q, searchLimit)
+		if err != nil {
+			return response.SearchResponse{}, err
+		}
+		resp.Tokens = toTokenResponses(tokens)
+	}
+	return resp, nil
+}
+
+func toTokenResponses(tokens []model.Token) []response.Token {
+	resp := make([]response.Token, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, toTokenResponse(token))
+	}
+	return resp
+}
+
+func toTokenResponse(token model.Token) response.Token {
+	return response.Token{
+		TokenPath: token.TokenPath,
+		Symbol:    TokenSymbol(token.TokenPath),
+		Holders:   token.Holders,
+	}
+}
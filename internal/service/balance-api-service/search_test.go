@@ -0,0 +1,33 @@
+package balance_api_service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestClassifyQuery(t *testing.T) {
+	tests := []struct {
+		q    string
+		kind string
+	}{
+		{"g17290cwvmrapvp869xfnhhawa8sm9edpufzat7d", SearchKindAddress},
+		{"757", SearchKindHeight},
+		{"hQyYZSXPQFNdr/j2xVm/+rJxAr/3iSGh1vEUQRzp2Tc=", SearchKindTransaction},
+		{"gno.land/r/gnoswap/v1/test_token", SearchKindPath},
+		{"gno.land", SearchKindPath},
+		{"foo", SearchKindSymbol},
+		{"g1short", SearchKindSymbol},
+		{"0", SearchKindSymbol},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			assert.Equal(t, tt.kind, ClassifyQuery(tt.q))
+		})
+	}
+}
+
+func TestTokenSymbol(t *testing.T) {
+	assert.Equal(t, "foo", TokenSymbol("gno.land/r/gnoswap/v1/test_token/foo"))
+	assert.Equal(t, "ugnot", TokenSymbol("ugnot"))
+}
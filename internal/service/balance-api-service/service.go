@@ -4,21 +4,31 @@ import (
 	"context"
 	"onbloc/internal/repository/postgresdb"
 	"onbloc/internal/response"
+	"onbloc/pkg/caching"
+	"time"
 )
 
 type Service struct {
 	repository *postgresdb.Repository
+	cache      responseCache
 }
 
-func NewService(repository *postgresdb.Repository) *Service {
-	return &Service{repository: repository}
+// NewService 의 cache 가 nil 이면 응답 캐싱을 사용하지 않는다.
+func NewService(repository *postgresdb.Repository, cache caching.Caching, cacheTTL time.Duration) *Service {
+	return &Service{repository: repository, cache: newResponseCache(cache, cacheTTL)}
 }
 
-func (s Service) GetTokenBalances(ctx context.Context, addr string) (response.BalancesResponse, error) {
-	balances, err := s.repository.GetBalancesByAddress(ctx, addr)
+func (s Service) GetTokenBalances(ctx context.Context, chainID, addr string) (response.BalancesResponse, error) {
+	return readThrough(ctx, s.cache, "balances", caching.AddressVersionKey(chainID, addr), chainID+":"+addr,
+		func() (response.BalancesResponse, error) { return s.getTokenBalances(ctx, chainID, addr) })
+}
+
+func (s Service) getTokenBalances(ctx context.Context, chainID, addr string) (response.BalancesResponse, error) {
+	balances, err := s.repository.GetBalancesByAddress(ctx, chainID, addr)
 	if err != nil {
 		return response.BalancesResponse{}, err
 	}
+	linkBalanceTraces(ctx, balances)
 
 	tokenBalances := make([]response.TokenBalance, 0, len(balances))
 	for _, balance := range balances {
@@ -33,8 +43,8 @@ func (s Service) GetTokenBalances(ctx context.Context, addr string) (response.Ba
 	}, nil
 }
 
-func (s Service) GetAllTokenBalances(ctx context.Context, offset, limit int) (response.BalancesResponse, error) {
-	balances, err := s.repository.GetAllBalances(ctx, offset, limit)
+func (s Service) GetAllTokenBalances(ctx context.Context, chainID string, offset, limit int) (response.BalancesResponse, error) {
+	balances, err := s.repository.GetAllBalances(ctx, chainID, offset, limit)
 	if err != nil {
 		return response.BalancesResponse{}, err
 	}
@@ -51,11 +61,19 @@ func (s Service) GetAllTokenBalances(ctx context.Context, offset, limit int) (re
 	}, nil
 }
 
-func (s Service) GetTokenPathBalanceByAddress(ctx context.Context, tokenPath, address string) (response.AccountBalancesResponse, error) {
-	balances, err := s.repository.GetTokenPathBalanceByAddress(ctx, tokenPath, address)
+func (s Service) GetTokenPathBalanceByAddress(ctx context.Context, chainID, tokenPath, address string) (response.AccountBalancesResponse, error) {
+	return readThrough(ctx, s.cache, "token_balance", caching.AddressVersionKey(chainID, address), chainID+":"+tokenPath+":"+address,
+		func() (response.AccountBalancesResponse, error) {
+			return s.getTokenPathBalanceByAddress(ctx, chainID, tokenPath, address)
+		})
+}
+
+func (s Service) getTokenPathBalanceByAddress(ctx context.Context, chainID, tokenPath, address string) (response.AccountBalancesResponse, error) {
+	balances, err := s.repository.GetTokenPathBalanceByAddress(ctx, chainID, tokenPath, address)
 	if err != nil {
 		return response.AccountBalancesResponse{}, err
 	}
+	linkBalanceTraces(ctx, balances)
 	accountBalances := make([]response.AccountBalance, 0, len(balances))
 	for _, balance := range balances {
 		accountBalances = append(accountBalances, response.AccountBalance{
@@ -69,11 +87,19 @@ func (s Service) GetTokenPathBalanceByAddress(ctx context.Context, tokenPath, ad
 	}, nil
 }
 
-func (s Service) GetAllTokenPathBalances(ctx context.Context, tokenPath string) (response.AccountBalancesResponse, error) {
-	balances, err := s.repository.GetAllTokenPathBalances(ctx, tokenPath)
+func (s Service) GetAllTokenPathBalances(ctx context.Context, chainID, tokenPath string) (response.AccountBalancesResponse, error) {
+	return readThrough(ctx, s.cache, "token_balances", caching.TokenVersionKey(chainID, tokenPath), chainID+":"+tokenPath,
+		func() (response.AccountBalancesResponse, error) {
+			return s.getAllTokenPathBalances(ctx, chainID, tokenPath)
+		})
+}
+
+func (s Service) getAllTokenPathBalances(ctx context.Context, chainID, tokenPath string) (response.AccountBalancesResponse, error) {
+	balances, err := s.repository.GetAllTokenPathBalances(ctx, chainID, tokenPath)
 	if err != nil {
 		return response.AccountBalancesResponse{}, err
 	}
+	linkBalanceTraces(ctx, balances)
 
 	accountBalances := make([]response.AccountBalance, 0, len(balances))
 	for _, balance := range balances {
@@ -88,10 +114,10 @@ func (s Service) GetAllTokenPathBalances(ctx context.Context, tokenPath string)
 	}, nil
 }
 
-func (s Service) GetTokenTransferHistoryByAddress(ctx context.Context, address string) (response.TransfersResponse, error) {
-	histories, err := s.repository.GetTokenTransferHistoryByAddress(ctx, address)
+func (s Service) GetTokenTransferHistoryByAddress(ctx context.Context, chainID, address string) (response.TransfersResponse, error) {
+	histories, err := s.repository.GetTokenTransferHistoryByAddress(ctx, chainID, address)
 	if err != nil {
-		return response.TransfersResponse{}, nil
+		return response.TransfersResponse{}, err
 	}
 
 	transferHistories := make([]response.Transfer, 0, len(histories))
@@ -108,10 +134,10 @@ func (s Service) GetTokenTransferHistoryByAddress(ctx context.Context, address s
 	}, nil
 }
 
-func (s Service) GetAllTokenTransferHistory(ctx context.Context) (response.TransfersResponse, error) {
-	histories, err := s.repository.GetTokenTransferHistories(ctx)
+func (s Service) GetAllTokenTransferHistory(ctx context.Context, chainID string) (response.TransfersResponse, error) {
+	histories, err := s.repository.GetTokenTransferHistories(ctx, chainID)
 	if err != nil {
-		return response.TransfersResponse{}, nil
+		return response.TransfersResponse{}, err
 	}
 
 	transferHistories := make([]response.Transfer, 0, len(histories))
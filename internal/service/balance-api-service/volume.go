@@ -0,0 +1,65 @@
+package balance_api_service
+
+import (
+	"context"
+	"fmt"
+	"onbloc/internal/response"
+	"onbloc/pkg/model"
+	"time"
+)
+
+const maxVolumeBuckets = 1000
+
+// GetTokenVolume 은 [from, to) 구간의 버킷을 반환하며, 이벤트가 없는 버킷은 0 으로 채운다.
+func (s Service) GetTokenVolume(ctx context.Context, chainID, tokenPath, interval string, from, to time.Time) (response.VolumeResponse, error) {
+	duration, exists := model.VolumeIntervals[interval]
+	if !exists {
+		return response.VolumeResponse{}, fmt.Errorf("unsupported interval %q: %w", interval, ErrInvalidArgument)
+	}
+
+	from = from.UTC().Truncate(duration)
+	to = to.UTC()
+	if !from.Before(to) {
+		return response.VolumeResponse{}, fmt.Errorf("from must be before to: %w", ErrInvalidArgument)
+	}
+	if to.Sub(from)/duration > maxVolumeBuckets {
+		return response.VolumeResponse{}, fmt.Errorf("too many buckets, max %d: %w", maxVolumeBuckets, ErrInvalidArgument)
+	}
+
+	volumes, err := s.repository.GetTokenVolumes(ctx, chainID, tokenPath, interval, from, to)
+	if err != nil {
+		return response.VolumeResponse{}, err
+	}
+
+	return response.VolumeResponse{
+		TokenPath: tokenPath,
+		Interval:  interval,
+		From:      from,
+		To:        to,
+		Buckets:   FillVolumeBuckets(volumes, from, to, duration),
+	}, nil
+}
+
+func FillVolumeBuckets(volumes []model.TokenVolume, from, to time.Time, duration time.Duration) []response.VolumeBucket {
+	byStart := make(map[time.Time]model.TokenVolume, len(volumes))
+	for _, volume := range volumes {
+		byStart[volume.BucketStart.UTC()] = volume
+	}
+
+	buckets := make([]response.VolumeBucket, 0, to.Sub(from)/duration+1)
+	for start := from; start.Before(to); start = start.Add(duration) {
+		volume := byStart[start]
+		buckets = append(buckets, response.VolumeBucket{
+			BucketStart:     start,
+			TransferCount:   volume.TransferCount,
+			TransferVolume:  volume.TransferVolume,
+			UniqueSenders:   volume.UniqueSenders,
+			UniqueReceivers: volume.UniqueReceivers,
+			MintCount:       volume.MintCount,
+			MintVolume:      volume.MintVolume,
+			BurnCount:       volume.BurnCount,
+			BurnVolume:      volume.BurnVolume,
+		})
+	}
+	return buckets
+}
@@ -0,0 +1,26 @@
+package balance_api_service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"onbloc/pkg/model"
+	"testing"
+	"time"
+)
+
+func TestFillVolumeBuckets(t *testing.T) {
+	from := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC)
+	to := from.Add(3 * time.Hour)
+	volumes := []model.TokenVolume{
+		{BucketStart: from.Add(time.Hour), TransferCount: 2, TransferVolume: 300, UniqueSenders: 1, UniqueReceivers: 2},
+	}
+
+	buckets := FillVolumeBuckets(volumes, from, to, time.Hour)
+
+	assert.Len(t, buckets, 3)
+	assert.Equal(t, from, buckets[0].BucketStart)
+	assert.Equal(t, int64(0), buckets[0].TransferCount)
+	assert.Equal(t, int64(2), buckets[1].TransferCount)
+	assert.Equal(t, int64(300), buckets[1].TransferVolume)
+	assert.Equal(t, int64(2), buckets[1].UniqueReceivers)
+	assert.Equal(t, from.Add(2*time.Hour), buckets[2].BucketStart)
+}
@@ -0,0 +1,148 @@
+package balance_api_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"onbloc/internal/request"
+	"onbloc/internal/response"
+	"onbloc/pkg/model"
+)
+
+// CreateWebhookSubscription 은 서명용 secret 을 생성하며, secret 은 생성 응답에서만 반환한다.
+func (s Service) CreateWebhookSubscription(ctx context.Context, chainID string, req request.WebhookSubscriptionRequest) (response.WebhookSubscription, error) {
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return response.WebhookSubscription{}, err
+	}
+
+	subscription := model.WebhookSubscription{
+		ChainID: chainID,
+		Secret:  secret,
+		Active:  true,
+	}
+	applyWebhookRequest(&subscription, req)
+	if err = s.repository.CreateWebhookSubscription(ctx, &subscription); err != nil {
+		return response.WebhookSubscription{}, err
+	}
+
+	resp := toWebhookSubscriptionResponse(subscription)
+	resp.Secret = subscription.Secret
+	return resp, nil
+}
+
+func (s Service) GetWebhookSubscriptions(ctx context.Context, chainID string) (response.WebhookSubscriptionsResponse, error) {
+	subscriptions, err := s.repository.GetWebhookSubscriptions(ctx, chainID)
+	if err != nil {
+		return response.WebhookSubscriptionsResponse{}, err
+	}
+
+	resp := make([]response.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		resp = append(resp, toWebhookSubscriptionResponse(subscription))
+	}
+	return response.WebhookSubscriptionsResponse{Subscriptions: resp}, nil
+}
+
+func (s Service) GetWebhookSubscription(ctx context.Context, chainID string, id int64) (response.WebhookSubscription, error) {
+	subscription, err := s.getWebhookSubscription(ctx, chainID, id)
+	if err != nil {
+		return response.WebhookSubscription{}, err
+	}
+	return toWebhookSubscriptionResponse(subscription), nil
+}
+
+func (s Service) UpdateWebhookSubscription(ctx context.Context, chainID string, id int64, req request.WebhookSubscriptionRequest) (response.WebhookSubscription, error) {
+	subscription, err := s.getWebhookSubscription(ctx, chainID, id)
+	if err != nil {
+		return response.WebhookSubscription{}, err
+	}
+
+	applyWebhookRequest(&subscription, req)
+	if err = s.repository.UpdateWebhookSubscription(ctx, &subscription); err != nil {
+		return response.WebhookSubscription{}, err
+	}
+	return toWebhookSubscriptionResponse(subscription), nil
+}
+
+func (s Service) DeleteWebhookSubscription(ctx context.Context, chainID string, id int64) error {
+	deleted, err := s.repository.DeleteWebhookSubscription(ctx, chainID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("webhook subscription %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func (s Service) GetWebhookDeliveries(ctx context.Context, chainID string, id int64, offset, limit int) (response.WebhookDeliveriesResponse, error) {
+	if _, err := s.getWebhookSubscription(ctx, chainID, id); err != nil {
+		return response.WebhookDeliveriesResponse{}, err
+	}
+
+	deliveries, err := s.repository.GetWebhookDeliveries(ctx, id, offset, limit)
+	if err != nil {
+		return response.WebhookDeliveriesResponse{}, err
+	}
+
+	resp := make([]response.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		resp = append(resp, response.WebhookDelivery{
+			ID:              delivery.ID,
+			TransactionHash: delivery.TransactionHash,
+			TxEventIndex:    delivery.TxEventIndex,
+			Status:          delivery.Status,
+			Attempts:        delivery.Attempts,
+			LastStatusCode:  delivery.LastStatusCode,
+			LastError:       delivery.LastError,
+			NextAttemptAt:   delivery.NextAttemptAt,
+			DeliveredAt:     delivery.DeliveredAt,
+			CreatedAt:       delivery.CreatedAt,
+		})
+	}
+	return response.WebhookDeliveriesResponse{Deliveries: resp}, nil
+}
+
+func (s Service) getWebhookSubscription(ctx context.Context, chainID string, id int64) (model.WebhookSubscription, error) {
+	subscription, err := s.repository.GetWebhookSubscription(ctx, chainID, id)
+	if errors.Is(err, ErrNotFound) {
+		return model.WebhookSubscription{}, fmt.Errorf("webhook subscription %d: %w", id, ErrNotFound)
+	}
+	return subscription, err
+}
+
+func applyWebhookRequest(subscription *model.WebhookSubscription, req request.WebhookSubscriptionRequest) {
+	subscription.URL = req.URL
+	subscription.Address = req.Address
+	subscription.TokenPath = req.TokenPath
+	subscription.EventFunc = req.EventFunc
+	subscription.MinAmount = req.MinAmount
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+}
+
+func toWebhookSubscriptionResponse(subscription model.WebhookSubscription) response.WebhookSubscription {
+	return response.WebhookSubscription{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Address:   subscription.Address,
+		TokenPath: subscription.TokenPath,
+		EventFunc: subscription.EventFunc,
+		MinAmount: subscription.MinAmount,
+		Active:    subscription.Active,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
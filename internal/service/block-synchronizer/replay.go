@@ -0,0 +1,93 @@
+package block_synchronizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"onbloc/internal/logging"
+	"onbloc/internal/tx-indexer"
+	"onbloc/pkg/model"
+)
+
+// ReplayBatch 는 저장된 트랜잭션에서 다시 추출한 높이 구간 하나의 토큰 이벤트이다.
+// TransactionHashes 는 이벤트가 없는 트랜잭션까지 포함한 구간의 모든 트랜잭션이다.
+type ReplayBatch struct {
+	FromHeight        int64
+	ToHeight          int64
+	TransactionHashes []string
+	Events            []model.TokenEvent
+}
+
+// ReplayHandler 는 재추출한 이벤트를 큐에 발행하거나 바로 적용한다. 같은 구간을 다시 처리해도 결과가 같아야 한다.
+type ReplayHandler func(ctx context.Context, batch ReplayBatch) error
+
+// ReplayStoredEvents 는 indexer 를 거치지 않고 저장된 transactions.response 에서 토큰 이벤트를 다시 추출한다.
+// 추출 규칙이 바뀌었을 때(새 이벤트 종류, 디코딩 버그 수정) 사용하며, 구간을 backFillBatchSize 단위로 나눠 handle 에 넘긴다.
+func (s Service) ReplayStoredEvents(ctx context.Context, fromHeight, toHeight int64, handle ReplayHandler) (replayed int, err error) {
+	ctx = logging.With(ctx, logging.KeyChainID, s.chainID)
+	for current := fromHeight; current <= toHeight; {
+		end := current + int64(s.backFillBatchSize) - 1
+		if end > toHeight {
+			end = toHeight
+		}
+		batch, err := s.loadReplayBatch(ctx, current, end)
+		if err != nil {
+			return replayed, err
+		}
+		if err = handle(ctx, batch); err != nil {
+			return replayed, fmt.Errorf("failed to replay events from %d to %d: %w", current, end, err)
+		}
+		replayed += len(batch.Events)
+		slog.InfoContext(ctx, "replayed stored events", logging.KeyFromHeight, current, logging.KeyToHeight, end, "events", len(batch.Events))
+		current = end + 1
+	}
+	return replayed, nil
+}
+
+func (s Service) loadReplayBatch(ctx context.Context, fromHeight, toHeight int64) (ReplayBatch, error) {
+	stored, err := s.repository.GetTransactionsByHeightRange(ctx, s.chainID, fromHeight, toHeight)
+	if err != nil {
+		return ReplayBatch{}, fmt.Errorf("failed to get transactions from %d to %d: %w", fromHeight, toHeight, err)
+	}
+	transactions, err := DecodeStoredTransactions(stored)
+	if err != nil {
+		return ReplayBatch{}, err
+	}
+
+	hashes := make([]string, len(stored))
+	for i, transaction := range stored {
+		hashes[i] = transaction.Hash
+	}
+	return ReplayBatch{
+		FromHeight:        fromHeight,
+		ToHeight:          toHeight,
+		TransactionHashes: hashes,
+		Events:            ExtractTokenEvents(s.chainID, transactions),
+	}, nil
+}
+
+// PublishReplayBatch 는 재추출한 이벤트를 큐에 발행한다. 이미 처리한 이벤트는 event-processor 가 중복으로 무시한다.
+func (s Service) PublishReplayBatch(ctx context.Context, batch ReplayBatch) error {
+	if published := s.publishEvents(ctx, batch.Events); published < len(batch.Events) {
+		return fmt.Errorf("published %d of %d events", published, len(batch.Events))
+	}
+	return nil
+}
+
+// DecodeStoredTransactions 는 저장된 트랜잭션을 이벤트 추출에 필요한 만큼(hash, 높이, response) 되살린다.
+func DecodeStoredTransactions(stored []model.BlockTransaction) ([]tx_indexer.Transaction, error) {
+	transactions := make([]tx_indexer.Transaction, 0, len(stored))
+	for _, transaction := range stored {
+		var response tx_indexer.TransactionResponse
+		if err := json.Unmarshal(transaction.Response, &response); err != nil {
+			return nil, fmt.Errorf("failed to decode response of %s: %w", transaction.Hash, err)
+		}
+		transactions = append(transactions, tx_indexer.Transaction{
+			Hash:        transaction.Hash,
+			BlockHeight: transaction.BlockHeight,
+			Response:    response,
+		})
+	}
+	return transactions, nil
+}
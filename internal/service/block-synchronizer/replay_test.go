@@ -0,0 +1,53 @@
+package block_synchronizer
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tx_indexer "onbloc/internal/tx-indexer"
+	"onbloc/pkg/model"
+	"os"
+	"testing"
+)
+
+func loadDummyTransaction(t *testing.T) tx_indexer.Transaction {
+	var transaction tx_indexer.Transaction
+	data, err := os.ReadFile("./testDummy.json")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &transaction))
+	return transaction
+}
+
+func TestExtractTokenEvents(t *testing.T) {
+	transaction := loadDummyTransaction(t)
+
+	events := ExtractTokenEvents(TestChainID, []tx_indexer.Transaction{transaction})
+	require.Len(t, events, 6)
+	for _, event := range events {
+		assert.Equal(t, TestChainID, event.ChainID)
+		assert.Equal(t, transaction.Hash, event.TransactionHash)
+		assert.Equal(t, EventTypeTransfer, event.Type)
+		// 인덱스는 response.events 안의 위치이므로 토큰 이벤트가 아닌 이벤트도 건너뛴 만큼 증가한다.
+		assert.Equal(t, EventTypeTransfer, transaction.Response.Events[event.TxEventIndex].Type)
+	}
+}
+
+func TestDecodeStoredTransactions(t *testing.T) {
+	transaction := loadDummyTransaction(t)
+
+	t.Run("저장된 response 로 같은 이벤트를 다시 추출", func(t *testing.T) {
+		stored, err := transaction.ToModel(TestChainID)
+		require.NoError(t, err)
+
+		decoded, err := DecodeStoredTransactions([]model.BlockTransaction{*stored})
+		require.NoError(t, err)
+
+		expected := ExtractTokenEvents(TestChainID, []tx_indexer.Transaction{transaction})
+		assert.Equal(t, expected, ExtractTokenEvents(TestChainID, decoded))
+	})
+
+	t.Run("response 가 깨져 있으면 오류", func(t *testing.T) {
+		_, err := DecodeStoredTransactions([]model.BlockTransaction{{Hash: "broken", Response: json.RawMessage(`{"events":`)}})
+		assert.ErrorContains(t, err, "broken")
+	})
+}
@@ -2,81 +2,141 @@ package block_synchronizer
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"onbloc/internal/health"
+	"onbloc/internal/leader"
+	"onbloc/internal/logging"
+	"onbloc/internal/metrics"
 	"onbloc/internal/repository/postgresdb"
+	"onbloc/internal/tracing"
 	"onbloc/internal/tx-indexer"
 	"onbloc/pkg/messaging"
+	"onbloc/pkg/model"
 	"time"
 )
 
 type Service struct {
+	chainID           string
 	backFillBatchSize int
 	syncInterval      time.Duration
 	indexerClient     *tx_indexer.Client
 	repository        *postgresdb.Repository
 	messageQueue      *messaging.SQSClient
+	progress          *health.SyncProgress
+	fencingToken      int64
+	verifyLease       func(ctx context.Context) error
 }
 
-func NewService(client *tx_indexer.Client, repository *postgresdb.Repository, queue *messaging.SQSClient, backFillBatchSize int, syncInterval time.Duration) *Service {
+func NewService(chainID string, client *tx_indexer.Client, repository *postgresdb.Repository, queue *messaging.SQSClient, backFillBatchSize int, syncInterval time.Duration) *Service {
 	return &Service{
+		chainID:           chainID,
 		indexerClient:     client,
 		repository:        repository,
 		messageQueue:      queue,
 		backFillBatchSize: backFillBatchSize,
 		syncInterval:      syncInterval,
+		progress:          health.NewSyncProgress(),
 	}
 }
 
 func (s Service) RunBackFill(ctx context.Context) error {
-	return s.runBackFill(ctx)
+	return s.runBackFill(logging.With(ctx, logging.KeyChainID, s.chainID))
+}
+
+func (s Service) ChainID() string {
+	return s.chainID
+}
+
+// WithFencingToken 은 리더 임기의 펜싱 토큰으로 커서를 옮기는 Service 를 반환한다.
+// 더 큰 토큰의 리더가 커서를 옮긴 뒤에는 커서 갱신이 postgresdb.ErrFenced 로 거부되어 동기화를 멈춘다.
+// verifyLease 는 블록 저장과 이벤트 발행 직전에 호출되어, 임대를 잃은 리더가 진행 중인 구간을 마저 쓰지 않게 한다.
+func (s Service) WithFencingToken(token int64, verifyLease func(ctx context.Context) error) *Service {
+	s.fencingToken = token
+	s.verifyLease = verifyLease
+	return &s
+}
+
+// checkLeader 는 리더 임기 밖(Resync, replay 등)에서는 아무것도 확인하지 않는다.
+func (s Service) checkLeader(ctx context.Context) error {
+	if s.verifyLease == nil {
+		return nil
+	}
+	return s.verifyLease(ctx)
+}
+
+// isFenced 는 다른 리더가 이어받아 이 임기의 동기화를 멈춰야 하는 오류인지 확인한다.
+func isFenced(err error) bool {
+	return errors.Is(err, postgresdb.ErrFenced) || errors.Is(err, leader.ErrLostLease)
+}
+
+// Progress 는 liveness 확인에 사용하는 동기화 진행 상태이다.
+func (s Service) Progress() *health.SyncProgress {
+	return s.progress
 }
 
 func (s Service) GetLatestHeight(ctx context.Context) (int64, error) {
-	return s.indexerClient.GetLatestBlockHeight(ctx)
+	start := time.Now()
+	height, err := s.indexerClient.GetLatestBlockHeight(ctx)
+	metrics.ObserveIndexerRequest(s.chainID, "latest_block_height", start, err)
+	return height, err
 }
 
+// RunRealtimeSync 는 ctx 가 취소될 때까지 syncInterval 마다 최신 높이까지 동기화한다.
 func (s Service) RunRealtimeSync(ctx context.Context) error {
+	ctx = logging.With(ctx, logging.KeyChainID, s.chainID)
 	ticker := time.NewTicker(s.syncInterval * time.Second)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
-			log.Println("sync done")
+			slog.InfoContext(ctx, "realtime sync stopped")
 			return ctx.Err()
 		case <-ticker.C:
-			lastProcessedHeight, currentBlockHeight, err := s.getHeightGap(ctx)
-			if err != nil {
-				log.Println(err)
-				continue
-			}
-
-			if lastProcessedHeight < currentBlockHeight {
-				for current := lastProcessedHeight; current < currentBlockHeight; {
-					end := current + int64(s.backFillBatchSize)
-					if end > currentBlockHeight {
-						end = currentBlockHeight
-					}
-					err = s.SyncBlockRange(ctx, lastProcessedHeight, currentBlockHeight)
-					if err != nil {
-						log.Println("fail to sync block: ", err)
-						continue
-					}
-
-					err = s.SyncTransactionRage(ctx, lastProcessedHeight, currentBlockHeight)
-					if err != nil {
-						log.Println("fail to sync block: ", err)
-						continue
-					}
-					current = end + 1
+			if err := s.syncToHead(ctx); err != nil {
+				if isFenced(err) {
+					slog.WarnContext(ctx, "realtime sync fenced by a newer leader")
+					return err
 				}
+				slog.ErrorContext(ctx, "failed to sync to head", logging.Err(err))
 			}
 		}
 	}
 }
 
+// syncToHead 는 저장 높이부터 최신 높이까지 backFillBatchSize 단위로 동기화한다.
+// 종료 요청(ctx 취소)은 구간 사이에서만 확인하므로, 진행 중인 구간은 저장과 이벤트 발행을 마치고 커서까지 옮긴 뒤 멈춘다.
+func (s Service) syncToHead(ctx context.Context) error {
+	lastProcessedHeight, currentBlockHeight, err := s.getHeightGap(ctx)
+	if err != nil {
+		return err
+	}
+
+	for current := lastProcessedHeight; current < currentBlockHeight; {
+		if ctx.Err() != nil {
+			return nil
+		}
+		end := current + int64(s.backFillBatchSize)
+		if end > currentBlockHeight {
+			end = currentBlockHeight
+		}
+		inflight := context.WithoutCancel(ctx)
+		if err = s.syncRange(inflight, current, end); err != nil {
+			return fmt.Errorf("failed to sync block range: %w", err)
+		}
+		if err = s.advanceCursor(inflight, end); err != nil {
+			return err
+		}
+		current = end
+	}
+	return nil
+}
+
 func (s Service) getHeightGap(ctx context.Context) (lastProcessed, current int64, err error) {
-	lastProcessed, err = s.repository.GetLatestHeight(ctx)
+	lastProcessed, err = s.GetStoredHeight(ctx)
 	if err != nil {
 		return 0, 0, fmt.Errorf("fail to get height from db: %w", err)
 	}
@@ -85,19 +145,29 @@ func (s Service) getHeightGap(ctx context.Context) (lastProcessed, current int64
 	if err != nil {
 		return 0, 0, fmt.Errorf("fail to get height from graphql: %w", err)
 	}
+	metrics.SetHeadLag(s.chainID, current, lastProcessed)
+	s.progress.Observe(lastProcessed, current)
 
 	return lastProcessed, current, nil
 }
 
+// backFillRetryDelay 는 back-fill 구간 동기화가 실패했을 때 같은 구간을 다시 시도하기 전 기다리는 시간이다.
+const backFillRetryDelay = 5 * time.Second
+
+// runBackFill 은 블록, 트랜잭션, 이벤트를 모두 처리한 구간만 커서를 옮긴다. 실패한 구간은 잠시 기다린 뒤 다시 시도한다.
+// ctx 가 취소되면 진행 중인 구간을 마친 뒤 ctx.Err() 를 반환한다.
 func (s Service) runBackFill(ctx context.Context) error {
 	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		lastProcessedHeight, currentBlockHeight, err := s.getHeightGap(ctx)
 		if err != nil {
 			return err
 		}
 
 		if lastProcessedHeight >= currentBlockHeight {
-			log.Println("backFill 종료")
+			slog.InfoContext(ctx, "back-fill caught up", logging.KeyToHeight, currentBlockHeight)
 			break
 		}
 
@@ -107,60 +177,173 @@ func (s Service) runBackFill(ctx context.Context) error {
 			end = currentBlockHeight
 		}
 
-		err = s.SyncBlockRange(ctx, start, end)
+		inflight := context.WithoutCancel(ctx)
+		if err = s.syncRange(inflight, start, end); err != nil {
+			if isFenced(err) {
+				return err
+			}
+			slog.ErrorContext(ctx, "failed to back-fill block range", logging.Err(err))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backFillRetryDelay):
+			}
+			continue
+		}
+		if err = s.advanceCursor(inflight, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStoredHeight 는 동기화를 이어갈 높이이다. 커서가 설정되어 있으면 커서를, 없으면 저장된 블록의 최대 높이를 사용한다.
+func (s Service) GetStoredHeight(ctx context.Context) (int64, error) {
+	height, found, err := s.repository.GetSyncCursor(ctx, s.chainID)
+	if err != nil {
+		return 0, err
+	}
+	if found {
+		return height, nil
+	}
+	return s.repository.GetLatestHeight(ctx, s.chainID)
+}
+
+func (s Service) advanceCursor(ctx context.Context, height int64) error {
+	err := s.repository.AdvanceSyncCursor(ctx, s.chainID, height, s.fencingToken)
+	if err != nil {
+		return fmt.Errorf("failed to save sync cursor at %d: %w", height, err)
+	}
+	return nil
+}
+
+// Resync 는 indexer 에서 블록과 트랜잭션을 다시 가져와 저장하고 이벤트를 다시 발행한다.
+// 이미 저장된 블록은 건너뛰고 트랜잭션은 messages 만 다시 가져온 값으로 맞추며, 이미 처리한 이벤트는 event-processor 가 중복으로 무시한다.
+// 커서는 바꾸지 않는다.
+func (s Service) Resync(ctx context.Context, fromHeight, toHeight int64) error {
+	ctx = logging.With(ctx, logging.KeyChainID, s.chainID)
+	for _, r := range resyncRanges(fromHeight, toHeight, s.backFillBatchSize) {
+		if err := s.syncRange(ctx, r[0], r[1]); err != nil {
+			return fmt.Errorf("failed to resync from %d to %d: %w", r[0]+1, r[1], err)
+		}
 	}
 	return nil
 }
 
+// resyncRanges 는 fromHeight 부터 toHeight 까지(양 끝 포함)를 batchSize 블록씩 syncRange 의 (from, to] 구간으로 나눈다.
+func resyncRanges(fromHeight, toHeight int64, batchSize int) [][2]int64 {
+	var ranges [][2]int64
+	for current := fromHeight - 1; current < toHeight; {
+		end := current + int64(batchSize)
+		if end > toHeight {
+			end = toHeight
+		}
+		ranges = append(ranges, [2]int64{current, end})
+		current = end
+	}
+	return ranges
+}
+
+// syncRange 는 블록과 트랜잭션 동기화를 하나의 트레이스로 묶는다. 발행한 이벤트의 처리 트레이스도 여기에 이어진다.
+func (s Service) syncRange(ctx context.Context, fromHeight, toHeight int64) (err error) {
+	ctx, span := tracing.Start(ctx, "sync.range", trace.WithAttributes(
+		attribute.String("chain_id", s.chainID),
+		attribute.Int64("from_height", fromHeight),
+		attribute.Int64("to_height", toHeight)))
+	defer func() { tracing.End(span, err) }()
+
+	if err = s.checkLeader(ctx); err != nil {
+		return err
+	}
+	if err = s.SyncBlockRange(ctx, fromHeight, toHeight); err != nil {
+		return err
+	}
+	return s.SyncTransactionRage(ctx, fromHeight, toHeight)
+}
+
 func (s Service) SyncBlockRange(ctx context.Context, fromHeight, toHeight int64) error {
+	ctx = logging.With(ctx, logging.KeyFromHeight, fromHeight, logging.KeyToHeight, toHeight)
 	// graphql에서 가져오기.
+	start := time.Now()
 	resp, err := s.indexerClient.GetBlocks(ctx, fromHeight, toHeight)
+	metrics.ObserveIndexerRequest(s.chainID, "get_blocks", start, err)
 	if err != nil {
 		return err
 	}
-	log.Println(fmt.Sprintf("save block start:%d, end:%d, block-len:%d", fromHeight, toHeight, len(resp.Blocks)))
+	slog.InfoContext(ctx, "fetched blocks", "blocks", len(resp.Blocks))
 
 	//db에 저장.
-	err = s.repository.InsertBlockRange(ctx, resp.ToModels())
+	err = s.repository.InsertBlockRange(ctx, resp.ToModels(s.chainID))
 	if err != nil {
 		return err
 	}
+	metrics.AddSyncedBlocks(s.chainID, len(resp.Blocks))
 	return nil
 }
 
 func (s Service) SyncTransactionRage(ctx context.Context, fromHeight, toHeight int64) error {
+	ctx = logging.With(ctx, logging.KeyFromHeight, fromHeight, logging.KeyToHeight, toHeight)
+	start := time.Now()
 	resp, err := s.indexerClient.GetTransactions(ctx, fromHeight, toHeight)
+	metrics.ObserveIndexerRequest(s.chainID, "get_transactions", start, err)
 	if err != nil {
 		return fmt.Errorf("failed to get transactions from %d to %d: %w", fromHeight, toHeight, err)
 	}
 
-	transactions := resp.ToModels()
+	transactions := resp.ToModels(s.chainID)
 	err = s.repository.InsertTransactions(ctx, transactions)
 	if err != nil {
 		return fmt.Errorf("failed to insert transactions: %w", err)
 	}
-	log.Printf("save transactions start:%d, end:%d, transaction-len:%d\n", fromHeight, toHeight, len(resp.GetTransactions))
-
-	s.publishTransactionEvents(ctx, resp.GetTransactions)
+	metrics.AddSyncedTransactions(s.chainID, len(transactions))
+	slog.InfoContext(ctx, "saved transactions", "transactions", len(resp.GetTransactions))
 
+	if _, err = s.publishTransactionEvents(ctx, resp.GetTransactions); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (s Service) publishTransactionEvents(ctx context.Context, transactions []tx_indexer.Transaction) {
-	for _, transaction := range transactions {
-		if len(transaction.Response.Events) == 0 {
-			break
+// publishTransactionEvents 는 발행 직전에 리더인지 다시 확인한다. 임대를 잃었으면 아무것도 발행하지 않는다.
+// 하나라도 발행하지 못하면 오류를 반환해 커서가 구간을 넘어가지 않게 한다. 다시 시도할 때 이미 발행한 이벤트는 event-processor 가 중복으로 무시한다.
+func (s Service) publishTransactionEvents(ctx context.Context, transactions []tx_indexer.Transaction) (published int, err error) {
+	if err = s.checkLeader(ctx); err != nil {
+		return 0, err
+	}
+	events := ExtractTokenEvents(s.chainID, transactions)
+	if published = s.publishEvents(ctx, events); published < len(events) {
+		return published, fmt.Errorf("published %d of %d events", published, len(events))
+	}
+	return published, nil
+}
+
+func (s Service) publishEvents(ctx context.Context, events []model.TokenEvent) (published int) {
+	for _, event := range events {
+		err := s.messageQueue.PublishMessage(ctx, event)
+		metrics.IncPublishedEvents(s.chainID, err)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to publish event",
+				logging.KeyTxHash, event.TransactionHash, logging.KeyEventIndex, event.TxEventIndex, logging.Err(err))
+			continue
 		}
+		published++
+	}
+	return
+}
+
+// ExtractTokenEvents 는 트랜잭션 response 의 GnoEvent 중 토큰 이벤트만 골라 변환한다.
+// 실시간 동기화와 저장된 트랜잭션 재처리(replay)가 같은 규칙을 사용하도록 추출 규칙은 여기에만 둔다.
+func ExtractTokenEvents(chainID string, transactions []tx_indexer.Transaction) []model.TokenEvent {
+	var events []model.TokenEvent
+	for _, transaction := range transactions {
 		for i, event := range transaction.Response.Events {
-			if !s.isTransferTokenEvent(event) {
+			if !isTransferTokenEvent(event) {
 				continue
 			}
-			err := s.messageQueue.PublishMessage(ctx, event.ToModel(i))
-			if err != nil {
-				log.Printf("fail to publish event: %v\n", event)
-			}
+			events = append(events, *event.ToModel(chainID, transaction.Hash, i))
 		}
 	}
+	return events
 }
 
 const (
@@ -181,7 +364,7 @@ var attrRequirements = map[string]attrRequirement{
 	EventFuncTransfer: {fromEmpty: false, toEmpty: false},
 }
 
-func (s Service) isTransferTokenEvent(event tx_indexer.Event) bool {
+func isTransferTokenEvent(event tx_indexer.Event) bool {
 	if event.GnoEvent.Type != EventTypeTransfer {
 		return false
 	}
@@ -190,10 +373,10 @@ func (s Service) isTransferTokenEvent(event tx_indexer.Event) bool {
 	if !exists {
 		return false
 	}
-	return s.validateEventAttrs(event, rule.fromEmpty, rule.toEmpty)
+	return validateEventAttrs(event, rule.fromEmpty, rule.toEmpty)
 }
 
-func (s Service) validateEventAttrs(event tx_indexer.Event, fromEmpty, toEmpty bool) bool {
+func validateEventAttrs(event tx_indexer.Event, fromEmpty, toEmpty bool) bool {
 	attrs := event.GetAttrs()
 	if len(attrs) != 3 {
 		return false
@@ -3,19 +3,29 @@ package block_synchronizer
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"onbloc/internal/leader"
+	"onbloc/internal/migration"
 	"onbloc/internal/repository/postgresdb"
 	tx_indexer "onbloc/internal/tx-indexer"
 	"onbloc/pkg/messaging"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
 
 var QueueUrl = "http://localhost:4566/000000000000/test-queue"
 
+var TestChainID = "dev"
+
 func TestService_SyncTransactionRage(t *testing.T) {
 	client := tx_indexer.NewClient("https://dev-indexer.api.gnoswap.io/graphql/query", 30*time.Second)
 	db, err := gorm.Open(postgres.Open("host=localhost user=postgres password=password dbname=onbloc port=5432 sslmode=disable"), &gorm.Config{})
@@ -23,7 +33,7 @@ func TestService_SyncTransactionRage(t *testing.T) {
 
 	repository := postgresdb.NewRepository(db)
 	messageQueue, err := messaging.NewSQSClient(context.TODO(), QueueUrl)
-	service := NewService(client, repository, messageQueue, 100, 5)
+	service := NewService(TestChainID, client, repository, messageQueue, 100, 5)
 
 	err = service.SyncTransactionRage(context.TODO(), 667, 669)
 	assert.Nil(t, err)
@@ -38,7 +48,7 @@ func TestService_publishTransactionEvents(t *testing.T) {
 	repository := postgresdb.NewRepository(db)
 	messageQueue, err := messaging.NewSQSClient(context.TODO(), QueueUrl)
 	assert.Nil(t, err)
-	service := NewService(client, repository, messageQueue, 100, 5)
+	service := NewService(TestChainID, client, repository, messageQueue, 100, 5)
 
 	var dummyTransactions tx_indexer.Transaction
 	dummyData, err := os.ReadFile("./testDummy.json")
@@ -47,10 +57,129 @@ func TestService_publishTransactionEvents(t *testing.T) {
 	err = json.Unmarshal(dummyData, &dummyTransactions)
 	assert.Nil(t, err)
 
-	service.publishTransactionEvents(context.TODO(), []tx_indexer.Transaction{dummyTransactions})
+	_, err = service.publishTransactionEvents(context.TODO(), []tx_indexer.Transaction{dummyTransactions})
+	assert.Nil(t, err)
 
 	messageCount := service.messageQueue.GetMessageCount(context.TODO())
 	assert.Equal(t, messageCount, 6)
 
 	defer messageQueue.CleanQueue(context.TODO(), QueueUrl)
 }
+
+func TestResyncRanges(t *testing.T) {
+	t.Run("fromHeight 를 포함하고 구간이 겹치지 않는다", func(t *testing.T) {
+		assert.Equal(t, [][2]int64{{9, 12}, {12, 15}, {15, 16}}, resyncRanges(10, 16, 3))
+	})
+
+	t.Run("한 높이만 다시 동기화한다", func(t *testing.T) {
+		assert.Equal(t, [][2]int64{{4, 5}}, resyncRanges(5, 5, 100))
+	})
+
+	t.Run("범위가 비어 있으면 구간이 없다", func(t *testing.T) {
+		assert.Empty(t, resyncRanges(6, 5, 100))
+	})
+}
+
+func TestService_publishTransactionEvents_lostLease(t *testing.T) {
+	transaction := loadDummyTransaction(t)
+	isLeader := true
+	// 큐가 없으므로 발행을 시도하면 실패한다.
+	service := NewService(TestChainID, nil, nil, nil, 100, 5).WithFencingToken(1, func(ctx context.Context) error {
+		if isLeader {
+			return nil
+		}
+		return leader.ErrLostLease
+	})
+	require.NoError(t, service.checkLeader(context.Background()))
+
+	// 구간을 처리하는 도중 다른 인스턴스가 임대를 가져갔다.
+	isLeader = false
+	published, err := service.publishTransactionEvents(context.Background(), []tx_indexer.Transaction{transaction})
+	assert.ErrorIs(t, err, leader.ErrLostLease)
+	assert.True(t, isFenced(err))
+	assert.Zero(t, published)
+}
+
+// newFakeIndexer 는 최신 높이가 1 이고 높이 1 에 transaction 하나가 있는 tx-indexer 를 흉내 낸다.
+func newFakeIndexer(t *testing.T, transaction tx_indexer.Transaction) *httptest.Server {
+	transaction.BlockHeight = 1
+	transaction.Messages = nil
+	transactionJSON, err := json.Marshal(transaction)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch query := string(body); {
+		case strings.Contains(query, "latestBlockHeight"):
+			fmt.Fprint(w, `{"data":{"latestBlockHeight":1}}`)
+		case strings.Contains(query, "getBlocks"):
+			fmt.Fprint(w, `{"data":{"getBlocks":[{"hash":"block-1","height":1,"time":"2024-01-01T00:00:00Z","num_txs":1,"total_txs":1}]}}`)
+		case strings.Contains(query, "getTransactions"):
+			fmt.Fprintf(w, `{"data":{"getTransactions":[%s]}}`, transactionJSON)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newFailingQueue 는 모든 발행을 재시도할 수 없는 오류로 거부하는 SQS 를 흉내 낸다.
+func newFailingQueue(t *testing.T) *messaging.SQSClient {
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"__type":"com.amazonaws.sqs#QueueDoesNotExist","message":"The specified queue does not exist."}`)
+	}))
+	t.Cleanup(server.Close)
+
+	queue, err := messaging.NewSQSClient(context.Background(), server.URL+"/000000000000/test-queue")
+	require.NoError(t, err)
+	return queue
+}
+
+func TestService_publishTransactionEvents_publishFailure(t *testing.T) {
+	service := NewService(TestChainID, nil, nil, newFailingQueue(t), 100, 5)
+	published, err := service.publishTransactionEvents(context.Background(), []tx_indexer.Transaction{loadDummyTransaction(t)})
+	assert.EqualError(t, err, "published 0 of 6 events")
+	assert.Zero(t, published)
+}
+
+func TestService_syncToHead_publishFailure(t *testing.T) {
+	ctx := context.Background()
+	dsn := "host=localhost user=postgres password=password dbname=onbloc port=5432 sslmode=disable"
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	schemaName := fmt.Sprintf("block_synchronizer_test_%d", time.Now().UnixNano())
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+schemaName).Error)
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schemaName + " CASCADE") })
+
+	db, err := gorm.Open(postgres.Open(dsn+" search_path="+schemaName+",public"), &gorm.Config{})
+	require.NoError(t, err)
+	migrator, err := migration.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	repository := postgresdb.NewRepository(db)
+
+	indexer := newFakeIndexer(t, loadDummyTransaction(t))
+	service := NewService(TestChainID, tx_indexer.NewClient(indexer.URL, time.Second), repository, newFailingQueue(t), 100, 5)
+
+	err = service.syncToHead(ctx)
+	assert.ErrorContains(t, err, "published 0 of 6 events")
+
+	// 블록과 트랜잭션은 저장했지만 이벤트를 발행하지 못했으므로 커서는 그대로다.
+	height, err := repository.GetLatestHeight(ctx, TestChainID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), height)
+	_, found, err := repository.GetSyncCursor(ctx, TestChainID)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	t.Run("Resync 도 실패로 끝난다", func(t *testing.T) {
+		assert.ErrorContains(t, service.Resync(ctx, 1, 1), "published 0 of 6 events")
+	})
+}
@@ -0,0 +1,39 @@
+package webhook_dispatcher
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"onbloc/internal/validation"
+	"syscall"
+	"time"
+)
+
+var ErrForbiddenAddress = errors.New("webhook target is not a public address")
+
+// NewHTTPClient 는 연결 직전에 실제 접속할 IP 를 확인해 loopback, link-local, 사설 주소로의 전송을 막는 클라이언트를 만든다.
+// 도메인이 생성 이후 내부 주소를 가리키도록 바뀌거나 리다이렉트로 내부 주소를 가리켜도 막히며, 같은 이유로 프록시는 사용하지 않는다.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   restrictDial,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+func restrictDial(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !validation.IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package webhook_dispatcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"onbloc/internal/health"
+	"onbloc/internal/logging"
+	"onbloc/internal/repository/postgresdb"
+	"onbloc/pkg/model"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderSignature = "X-Onbloc-Signature"
+	HeaderDelivery  = "X-Onbloc-Delivery"
+
+	baseBackoff = 10 * time.Second
+	maxBackoff  = time.Hour
+)
+
+type Service struct {
+	repository   *postgresdb.Repository
+	httpClient   *http.Client
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	heartbeat    *health.Heartbeat
+}
+
+func NewService(repository *postgresdb.Repository, httpClient *http.Client, pollInterval time.Duration, batchSize, maxAttempts int) *Service {
+	return &Service{
+		repository:   repository,
+		httpClient:   httpClient,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+		maxAttempts:  maxAttempts,
+		heartbeat:    health.NewHeartbeat(),
+	}
+}
+
+// Heartbeat 는 전달 대상을 정상적으로 조회하거나 전달 하나를 마칠 때마다 갱신된다. 보낼 것이 없어도 갱신되므로,
+// 오래 갱신되지 않으면 전달 루프가 멈춘 것이다.
+func (s Service) Heartbeat() *health.Heartbeat {
+	return s.heartbeat
+}
+
+func (s Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := s.dispatchDue(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to dispatch webhooks", logging.Err(err))
+				continue
+			}
+			s.heartbeat.Beat()
+		}
+	}
+}
+
+func (s Service) dispatchDue(ctx context.Context) error {
+	deliveries, err := s.repository.ClaimDueWebhookDeliveries(ctx, s.batchSize, 2*s.httpClient.Timeout+time.Minute)
+	if err != nil || len(deliveries) == 0 {
+		return err
+	}
+
+	ids := make([]int64, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.SubscriptionID)
+	}
+	subscriptions, err := s.repository.GetWebhookSubscriptionsByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	subscriptionByID := make(map[int64]model.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscriptionByID[subscription.ID] = subscription
+	}
+
+	for _, delivery := range deliveries {
+		subscription, exists := subscriptionByID[delivery.SubscriptionID]
+		if !exists || !subscription.Active {
+			delivery.Status = model.WebhookDeliveryFailed
+			delivery.LastError = "subscription inactive"
+			if err = s.repository.RecordWebhookAttempt(ctx, delivery, model.WebhookDeliveryAttempt{DeliveryID: delivery.ID, Attempt: delivery.Attempts, Error: delivery.LastError}); err != nil {
+				slog.ErrorContext(ctx, "failed to record webhook attempt", "delivery_id", delivery.ID, logging.Err(err))
+			}
+			continue
+		}
+
+		if err = s.deliver(ctx, subscription, delivery); err != nil {
+			slog.ErrorContext(ctx, "failed to record webhook attempt", "delivery_id", delivery.ID, logging.Err(err))
+		}
+		s.heartbeat.Beat()
+	}
+	return nil
+}
+
+func (s Service) deliver(ctx context.Context, subscription model.WebhookSubscription, delivery model.WebhookDelivery) error {
+	start := time.Now()
+	statusCode, sendErr := s.send(ctx, subscription, delivery)
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+	attempt := model.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+
+	switch {
+	case sendErr == nil:
+		now := time.Now()
+		delivery.Status = model.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.maxAttempts:
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.LastError = sendErr.Error()
+	default:
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = time.Now().Add(Backoff(delivery.Attempts))
+	}
+	attempt.Error = delivery.LastError
+
+	return s.repository.RecordWebhookAttempt(ctx, delivery, attempt)
+}
+
+func (s Service) send(ctx context.Context, subscription model.WebhookSubscription, delivery model.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(model.WebhookPayload{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: subscription.ID,
+		Event:          delivery.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderSignature, fmt.Sprintf("t=%d,v1=%s", timestamp, Sign(subscription.Secret, timestamp, body)))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign 은 "{timestamp}.{body}" 의 HMAC-SHA256 을 hex 로 반환한다. 수신 측은 같은 방식으로 서명을 검증한다.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff 는 attempt 번째 실패 후 다음 시도까지의 대기 시간이다. (10s, 20s, 40s ... 최대 1h)
+func Backoff(attempt int) time.Duration {
+	backoff := baseBackoff
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,79 @@
+package webhook_dispatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"onbloc/pkg/model"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, Backoff(1))
+	assert.Equal(t, 20*time.Second, Backoff(2))
+	assert.Equal(t, 40*time.Second, Backoff(3))
+	assert.Equal(t, time.Hour, Backoff(20))
+}
+
+func TestService_send(t *testing.T) {
+	subscription := model.WebhookSubscription{ID: 7, Secret: "secret"}
+	delivery := model.WebhookDelivery{ID: 42, Payload: json.RawMessage(`{"chainId":"dev","amount":100}`)}
+
+	t.Run("서명 헤더와 본문 전송", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			var timestamp int64
+			var signature string
+			_, err := fmt.Sscanf(r.Header.Get(HeaderSignature), "t=%d,v1=%s", &timestamp, &signature)
+			assert.Nil(t, err)
+			assert.Equal(t, Sign("secret", timestamp, body), signature)
+			assert.Equal(t, "42", r.Header.Get(HeaderDelivery))
+			assert.JSONEq(t, `{"deliveryId":42,"subscriptionId":7,"event":{"chainId":"dev","amount":100}}`, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		subscription.URL = server.URL
+		service := NewService(nil, server.Client(), time.Second, 10, 3)
+		statusCode, err := service.send(context.TODO(), subscription, delivery)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, statusCode)
+	})
+
+	t.Run("2xx 가 아니면 에러", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		subscription.URL = server.URL
+		service := NewService(nil, server.Client(), time.Second, 10, 3)
+		statusCode, err := service.send(context.TODO(), subscription, delivery)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+	})
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("loopback 주소로는 연결하지 않는다", func(t *testing.T) {
+		_, err := NewHTTPClient(time.Second).Post(server.URL, "application/json", nil)
+		assert.ErrorIs(t, err, ErrForbiddenAddress)
+	})
+
+	t.Run("공인 주소가 아니면 연결 전에 거부한다", func(t *testing.T) {
+		for _, address := range []string{"127.0.0.1:80", "[::1]:443", "169.254.169.254:80", "10.0.0.5:8080", "192.168.0.10:443"} {
+			assert.ErrorIs(t, restrictDial("tcp", address, nil), ErrForbiddenAddress, address)
+		}
+		assert.NoError(t, restrictDial("tcp", "203.0.113.10:443", nil))
+	})
+}
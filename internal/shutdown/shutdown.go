@@ -0,0 +1,24 @@
+package shutdown
+
+import (
+	"sync"
+	"time"
+)
+
+// Drain 은 wg 의 작업이 모두 끝나거나 timeout 이 지날 때까지 기다린다. 시간 안에 끝나면 true 를 반환한다.
+func Drain(wg *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
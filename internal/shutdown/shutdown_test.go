@@ -0,0 +1,32 @@
+package shutdown
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	t.Run("작업이 시간 안에 끝나면 true", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(10 * time.Millisecond)
+		}()
+		assert.True(t, Drain(&wg, time.Second))
+	})
+
+	t.Run("작업이 없으면 바로 true", func(t *testing.T) {
+		var wg sync.WaitGroup
+		assert.True(t, Drain(&wg, time.Millisecond))
+	})
+
+	t.Run("시간 안에 끝나지 않으면 false", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		defer wg.Done()
+		assert.False(t, Drain(&wg, 10*time.Millisecond))
+	})
+}
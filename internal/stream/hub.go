@@ -0,0 +1,140 @@
+package stream
+
+import (
+	"log/slog"
+	"onbloc/internal/response"
+	"onbloc/pkg/model"
+	"sync"
+)
+
+const subscriberBufferSize = 64
+
+// Hub 는 LISTEN 으로 받은 알림을 구독 조건(주소, 토큰 경로)에 맞는 구독자에게 전달한다.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscriber]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[*Subscriber]struct{})}
+}
+
+type Subscriber struct {
+	chainID   string
+	mu        sync.RWMutex
+	addresses map[string]struct{}
+	tokens    map[string]struct{}
+	messages  chan response.StreamMessage
+}
+
+func (s *Subscriber) Messages() <-chan response.StreamMessage {
+	return s.messages
+}
+
+func (s *Subscriber) Subscribe(addresses, tokens []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, address := range addresses {
+		s.addresses[address] = struct{}{}
+	}
+	for _, token := range tokens {
+		s.tokens[token] = struct{}{}
+	}
+}
+
+func (s *Subscriber) Unsubscribe(addresses, tokens []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, address := range addresses {
+		delete(s.addresses, address)
+	}
+	for _, token := range tokens {
+		delete(s.tokens, token)
+	}
+}
+
+func (s *Subscriber) matches(address, token string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if _, exists := s.addresses[address]; exists && address != "" {
+		return true
+	}
+	_, exists := s.tokens[token]
+	return exists
+}
+
+func (h *Hub) Subscribe(chainID string, addresses, tokens []string) *Subscriber {
+	subscriber := &Subscriber{
+		chainID:   chainID,
+		addresses: make(map[string]struct{}),
+		tokens:    make(map[string]struct{}),
+		messages:  make(chan response.StreamMessage, subscriberBufferSize),
+	}
+	subscriber.Subscribe(addresses, tokens)
+
+	h.mu.Lock()
+	h.subscribers[subscriber] = struct{}{}
+	h.mu.Unlock()
+	return subscriber
+}
+
+func (h *Hub) Unsubscribe(subscriber *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, exists := h.subscribers[subscriber]; exists {
+		delete(h.subscribers, subscriber)
+		close(subscriber.messages)
+	}
+}
+
+// Publish 는 느린 구독자 때문에 전체가 막히지 않도록 버퍼가 찬 구독자의 메시지는 버린다.
+func (h *Hub) Publish(notification model.TokenEventNotification) {
+	event := notification.Event
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for subscriber := range h.subscribers {
+		if subscriber.chainID != event.ChainID {
+			continue
+		}
+
+		if subscriber.matches(event.From, event.PkgPath) || subscriber.matches(event.To, event.PkgPath) {
+			h.send(subscriber, response.StreamMessage{
+				Type:    response.StreamMessageTransfer,
+				ChainID: event.ChainID,
+				Transfer: &response.StreamTransfer{
+					TransactionHash: event.TransactionHash,
+					TxEventIndex:    event.TxEventIndex,
+					Func:            event.Func,
+					FromAddress:     event.From,
+					ToAddress:       event.To,
+					TokenPath:       event.PkgPath,
+					Amount:          event.Amount,
+				},
+			})
+		}
+
+		for _, balance := range notification.Balances {
+			if !subscriber.matches(balance.Address, balance.TokenPath) {
+				continue
+			}
+			h.send(subscriber, response.StreamMessage{
+				Type:    response.StreamMessageBalance,
+				ChainID: event.ChainID,
+				Balance: &response.AccountBalance{
+					Address:   balance.Address,
+					TokenPath: balance.TokenPath,
+					Amount:    balance.Amount,
+				},
+			})
+		}
+	}
+}
+
+func (h *Hub) send(subscriber *Subscriber, message response.StreamMessage) {
+	select {
+	case subscriber.messages <- message:
+	default:
+		slog.Warn("stream subscriber buffer full, message dropped", "type", message.Type)
+	}
+}
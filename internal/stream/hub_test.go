@@ -0,0 +1,62 @@
+package stream
+
+import (
+	"github.com/stretchr/testify/assert"
+	"onbloc/internal/response"
+	"onbloc/pkg/model"
+	"testing"
+)
+
+func TestHub_Publish(t *testing.T) {
+	notification := model.TokenEventNotification{
+		Event: model.TokenEvent{
+			ChainID: "dev",
+			Func:    "Transfer",
+			PkgPath: "gno.land/r/gnoswap/v1/test_token/foo",
+			From:    "g1from",
+			To:      "g1to",
+			Amount:  100,
+		},
+		Balances: []model.Balance{
+			{ChainID: "dev", Address: "g1from", TokenPath: "gno.land/r/gnoswap/v1/test_token/foo", Amount: 900},
+			{ChainID: "dev", Address: "g1to", TokenPath: "gno.land/r/gnoswap/v1/test_token/foo", Amount: 100},
+		},
+	}
+
+	t.Run("주소 구독 시 해당 주소의 전송과 잔액만 수신", func(t *testing.T) {
+		hub := NewHub()
+		subscriber := hub.Subscribe("dev", []string{"g1to"}, nil)
+		hub.Publish(notification)
+
+		assert.Len(t, subscriber.messages, 2)
+		transfer := <-subscriber.Messages()
+		assert.Equal(t, response.StreamMessageTransfer, transfer.Type)
+		assert.Equal(t, int64(100), transfer.Transfer.Amount)
+		balance := <-subscriber.Messages()
+		assert.Equal(t, response.StreamMessageBalance, balance.Type)
+		assert.Equal(t, "g1to", balance.Balance.Address)
+	})
+
+	t.Run("토큰 구독 시 모든 잔액 변경 수신", func(t *testing.T) {
+		hub := NewHub()
+		subscriber := hub.Subscribe("dev", nil, []string{"gno.land/r/gnoswap/v1/test_token/foo"})
+		hub.Publish(notification)
+
+		assert.Len(t, subscriber.messages, 3)
+	})
+
+	t.Run("다른 체인 또는 구독 해제 후에는 수신하지 않음", func(t *testing.T) {
+		hub := NewHub()
+		other := hub.Subscribe("test5", []string{"g1to"}, nil)
+		unsubscribed := hub.Subscribe("dev", []string{"g1to"}, nil)
+		unsubscribed.Unsubscribe([]string{"g1to"}, nil)
+		hub.Publish(notification)
+
+		assert.Len(t, other.messages, 0)
+		assert.Len(t, unsubscribed.messages, 0)
+
+		hub.Unsubscribe(other)
+		_, ok := <-other.Messages()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,57 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+	"onbloc/internal/logging"
+	"onbloc/pkg/model"
+	"time"
+)
+
+const reconnectInterval = 3 * time.Second
+
+// Listen 은 TokenEventChannel 을 LISTEN 하여 Hub 로 전달한다. 연결이 끊기면 재연결한다.
+// API 레플리카마다 각자 LISTEN 하므로 여러 레플리카에서도 동일하게 동작한다.
+func Listen(ctx context.Context, dsn string, hub *Hub) {
+	for {
+		err := listen(ctx, dsn, hub)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.WarnContext(ctx, "stream listener disconnected", logging.Err(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+func listen(ctx context.Context, dsn string, hub *Hub) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{model.TokenEventChannel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var tokenEventNotification model.TokenEventNotification
+		if err = json.Unmarshal([]byte(notification.Payload), &tokenEventNotification); err != nil {
+			slog.WarnContext(ctx, "failed to unmarshal notification", logging.Err(err))
+			continue
+		}
+		hub.Publish(tokenEventNotification)
+	}
+}
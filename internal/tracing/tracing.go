@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"onbloc/internal/config"
+)
+
+const instrumentationName = "onbloc"
+
+// Init 은 전역 TracerProvider 와 W3C trace context 전파기를 설정하고, 종료 시 남은 span 을 내보내는 함수를 반환한다.
+// Exporter 가 비어 있으면 전파만 하고 span 은 내보내지 않는다.
+func Init(ctx context.Context, conf config.Tracing, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch conf.Exporter {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(conf.Endpoint)}
+		if conf.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter: %s", conf.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	ratio := conf.GetSampleRatio()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start 는 전역 TracerProvider 로 span 을 시작한다. Init 전이나 exporter 가 없으면 아무것도 기록하지 않는다.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End 는 err 가 있으면 span 에 기록하고 종료한다.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent 는 ctx 의 span 을 W3C traceparent 문자열로 반환한다. 기록 중인 span 이 없으면 빈 문자열이다.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// LinkTraceParents 는 현재 span 에 traceparent 들이 가리키는 span 으로의 링크를 추가한다.
+// 잔액을 조회할 때 그 잔액을 마지막으로 갱신한 이벤트 처리 트레이스를 따라갈 수 있다.
+func LinkTraceParents(ctx context.Context, traceParents ...string) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	seen := make(map[string]struct{}, len(traceParents))
+	for _, traceParent := range traceParents {
+		if traceParent == "" {
+			continue
+		}
+		if _, exists := seen[traceParent]; exists {
+			continue
+		}
+		seen[traceParent] = struct{}{}
+
+		linked := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceParent}))
+		if linked.IsValid() {
+			span.AddLink(trace.Link{SpanContext: linked})
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"onbloc/internal/config"
+	"onbloc/pkg/messaging"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Run("메시지에 담긴 트레이스 컨텍스트로 수신 span 이 발행 트레이스에 이어진다", func(t *testing.T) {
+		ctx, publish := Start(context.Background(), "sqs.publish")
+		message := messaging.MessageObject{TraceContext: map[string]string{}}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(message.TraceContext))
+		publish.End()
+
+		_, consume := Start(message.Context(context.Background()), "sqs.consume")
+		consume.End()
+
+		assert.Equal(t, publish.SpanContext().TraceID(), consume.SpanContext().TraceID())
+	})
+
+	t.Run("TraceParent 로 저장한 span 을 조회 span 에 링크한다", func(t *testing.T) {
+		ctx, process := Start(context.Background(), "ProcessEvent")
+		traceParent := TraceParent(ctx)
+		process.End()
+		require.NotEmpty(t, traceParent)
+
+		exporter.Reset()
+		ctx, read := Start(context.Background(), "GET /tokens/balances")
+		LinkTraceParents(ctx, traceParent, traceParent, "", "invalid")
+		read.End()
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		require.Len(t, spans[0].Links, 1)
+		assert.Equal(t, process.SpanContext().SpanID(), spans[0].Links[0].SpanContext.SpanID())
+	})
+
+	t.Run("span 이 없으면 TraceParent 는 빈 문자열이다", func(t *testing.T) {
+		assert.Empty(t, TraceParent(context.Background()))
+	})
+}
+
+func TestInit(t *testing.T) {
+	t.Run("지원하지 않는 exporter 는 오류를 반환한다", func(t *testing.T) {
+		_, err := Init(context.Background(), config.Tracing{Exporter: "zipkin"}, "test")
+		assert.Error(t, err)
+	})
+
+	t.Run("exporter 가 없으면 전파기만 설정한다", func(t *testing.T) {
+		shutdown, err := Init(context.Background(), config.Tracing{}, "test")
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+		assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+	})
+}
@@ -3,7 +3,10 @@ package tx_indexer
 import (
 	"context"
 	"github.com/shurcooL/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"net/http"
+	"onbloc/internal/tracing"
 	"time"
 )
 
@@ -19,7 +22,11 @@ func NewClient(url string, timeout time.Duration) *Client {
 	return &Client{client: client}
 }
 
-func (c *Client) GetBlocks(ctx context.Context, fromHeight, toHeight int64) (*GetBlocksResponse, error) {
+func (c *Client) GetBlocks(ctx context.Context, fromHeight, toHeight int64) (_ *GetBlocksResponse, err error) {
+	ctx, span := tracing.Start(ctx, "indexer.GetBlocks", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int64("from_height", fromHeight), attribute.Int64("to_height", toHeight)))
+	defer func() { tracing.End(span, err) }()
+
 	variables := map[string]interface{}{
 		"gt": graphql.Int(fromHeight),
 		"lt": graphql.Int(toHeight + 1),
@@ -28,7 +35,7 @@ func (c *Client) GetBlocks(ctx context.Context, fromHeight, toHeight int64) (*Ge
 	var query struct {
 		GetBlocks []Block `graphql:"getBlocks(where: {height: {gt: $gt, lt: $lt}})"`
 	}
-	err := c.client.Query(ctx, &query, variables)
+	err = c.client.Query(ctx, &query, variables)
 	if err != nil {
 		return nil, err
 	}
@@ -38,12 +45,15 @@ func (c *Client) GetBlocks(ctx context.Context, fromHeight, toHeight int64) (*Ge
 	}, nil
 }
 
-func (c Client) GetLatestBlockHeight(ctx context.Context) (int64, error) {
+func (c Client) GetLatestBlockHeight(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "indexer.GetLatestBlockHeight", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
+
 	var query struct {
 		LatestBlockHeight int64 `graphql:"latestBlockHeight"`
 	}
 
-	err := c.client.Query(ctx, &query, nil)
+	err = c.client.Query(ctx, &query, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -51,7 +61,11 @@ func (c Client) GetLatestBlockHeight(ctx context.Context) (int64, error) {
 	return query.LatestBlockHeight, nil
 }
 
-func (c *Client) GetTransactions(ctx context.Context, fromHeight, toHeight int64) (*GetTransactionsResponse, error) {
+func (c *Client) GetTransactions(ctx context.Context, fromHeight, toHeight int64) (_ *GetTransactionsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "indexer.GetTransactions", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int64("from_height", fromHeight), attribute.Int64("to_height", toHeight)))
+	defer func() { tracing.End(span, err) }()
+
 	var query struct {
 		GetTransactions []Transaction `graphql:"getTransactions(where: {block_height: {gt: $gt, lt: $lt}, index: {lt: 1000}})"`
 	}
@@ -61,7 +75,7 @@ func (c *Client) GetTransactions(ctx context.Context, fromHeight, toHeight int64
 		"lt": graphql.Int(toHeight + 1),
 	}
 
-	err := c.client.Query(ctx, &query, variables)
+	err = c.client.Query(ctx, &query, variables)
 	if err != nil {
 		return nil, err
 	}
@@ -70,3 +84,9 @@ func (c *Client) GetTransactions(ctx context.Context, fromHeight, toHeight int64
 		GetTransactions: query.GetTransactions,
 	}, nil
 }
+
+// Ping 은 최신 블록 높이를 조회해 indexer 에 접근 가능한지 확인한다.
+func (c Client) Ping(ctx context.Context) error {
+	_, err := c.GetLatestBlockHeight(ctx)
+	return err
+}
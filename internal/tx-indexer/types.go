@@ -2,7 +2,8 @@ package tx_indexer
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
+	"onbloc/internal/logging"
 	"onbloc/pkg/model"
 	"strconv"
 	"time"
@@ -12,10 +13,10 @@ type GetBlocksResponse struct {
 	Blocks []Block
 }
 
-func (g GetBlocksResponse) ToModels() []*model.Block {
+func (g GetBlocksResponse) ToModels(chainID string) []*model.Block {
 	blocks := make([]*model.Block, len(g.Blocks))
 	for i, block := range g.Blocks {
-		blocks[i] = block.ToModel()
+		blocks[i] = block.ToModel(chainID)
 	}
 	return blocks
 }
@@ -28,8 +29,9 @@ type Block struct {
 	TotalTxs int64     `graphql:"total_txs"`
 }
 
-func (b Block) ToModel() *model.Block {
+func (b Block) ToModel(chainID string) *model.Block {
 	return &model.Block{
+		ChainID:  chainID,
 		Hash:     b.Hash,
 		Height:   b.Height,
 		Time:     b.Time,
@@ -51,12 +53,13 @@ type GetTransactionsResponse struct {
 	GetTransactions []Transaction
 }
 
-func (r GetTransactionsResponse) ToModels() []*model.BlockTransaction {
+func (r GetTransactionsResponse) ToModels(chainID string) []*model.BlockTransaction {
 	transactions := make([]*model.BlockTransaction, len(r.GetTransactions))
 	for i, transaction := range r.GetTransactions {
-		trx, err := transaction.ToModel()
+		trx, err := transaction.ToModel(chainID)
 		if err != nil {
-			log.Printf("fail to convert transaction at %s, %d\n", transaction.Hash, transaction.BlockHeight)
+			slog.Warn("failed to convert transaction", logging.KeyChainID, chainID,
+				logging.KeyTxHash, transaction.Hash, "height", transaction.BlockHeight, logging.Err(err))
 			continue
 		}
 		transactions[i] = trx
@@ -66,19 +69,19 @@ func (r GetTransactionsResponse) ToModels() []*model.BlockTransaction {
 }
 
 type Transaction struct {
-	Index       int64               `graphql:"index"`
-	Hash        string              `graphql:"hash"`
-	Success     bool                `graphql:"success"`
-	BlockHeight int64               `graphql:"block_height"`
-	GasWanted   int64               `graphql:"gas_wanted"`
-	GasUsed     int64               `graphql:"gas_used"`
-	Memo        string              `graphql:"memo"`
-	GasFee      GasFee              `graphql:"gas_fee"`
-	Messages    []Message           `graphql:"messages"`
-	Response    TransactionResponse `graphql:"response"`
-}
-
-func (t Transaction) ToModel() (*model.BlockTransaction, error) {
+	Index       int64               `json:"index" graphql:"index"`
+	Hash        string              `json:"hash" graphql:"hash"`
+	Success     bool                `json:"success" graphql:"success"`
+	BlockHeight int64               `json:"block_height" graphql:"block_height"`
+	GasWanted   int64               `json:"gas_wanted" graphql:"gas_wanted"`
+	GasUsed     int64               `json:"gas_used" graphql:"gas_used"`
+	Memo        string              `json:"memo" graphql:"memo"`
+	GasFee      GasFee              `json:"gas_fee" graphql:"gas_fee"`
+	Messages    []Message           `json:"messages" graphql:"messages"`
+	Response    TransactionResponse `json:"response" graphql:"response"`
+}
+
+func (t Transaction) ToModel(chainID string) (*model.BlockTransaction, error) {
 	gasFeeBytes, err := json.Marshal(t.GasFee)
 	if err != nil {
 		return nil, err
@@ -95,6 +98,7 @@ func (t Transaction) ToModel() (*model.BlockTransaction, error) {
 	}
 
 	return &model.BlockTransaction{
+		ChainID:     chainID,
 		IndexNum:    t.Index,
 		Hash:        t.Hash,
 		BlockHeight: t.BlockHeight,
@@ -110,66 +114,126 @@ func (t Transaction) ToModel() (*model.BlockTransaction, error) {
 }
 
 type GasFee struct {
-	Amount int64  `graphql:"amount"`
-	Denom  string `graphql:"denom"`
+	Amount int64  `json:"amount" graphql:"amount"`
+	Denom  string `json:"denom" graphql:"denom"`
 }
 
+const (
+	MessageTypeSend       = "send"
+	MessageTypeCall       = "exec"
+	MessageTypeAddPackage = "add_package"
+	MessageTypeRun        = "run"
+)
+
 type Message struct {
 	Route   string       `graphql:"route"`
 	TypeUrl string       `graphql:"typeUrl"`
 	Value   MessageValue `graphql:"value"`
 }
 
+type messageJSON struct {
+	Route   string          `json:"route"`
+	TypeUrl string          `json:"typeUrl"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// MarshalJSON 은 MessageValue 의 fragment 중 TypeUrl 에 해당하는 값만 직렬화한다.
+// (fragment 를 그대로 직렬화하면 caller, send, package 처럼 겹치는 필드가 누락된다.)
+func (m Message) MarshalJSON() ([]byte, error) {
+	var value interface{}
+	switch m.TypeUrl {
+	case MessageTypeSend:
+		value = m.Value.BankMsgSend
+	case MessageTypeCall:
+		value = m.Value.MsgCall
+	case MessageTypeAddPackage:
+		value = m.Value.MsgAddPackage
+	case MessageTypeRun:
+		value = m.Value.MsgRun
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(messageJSON{Route: m.Route, TypeUrl: m.TypeUrl, Value: valueBytes})
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var raw messageJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	m.Route = raw.Route
+	m.TypeUrl = raw.TypeUrl
+	if len(raw.Value) == 0 || string(raw.Value) == "null" {
+		return nil
+	}
+
+	switch m.TypeUrl {
+	case MessageTypeSend:
+		return json.Unmarshal(raw.Value, &m.Value.BankMsgSend)
+	case MessageTypeCall:
+		return json.Unmarshal(raw.Value, &m.Value.MsgCall)
+	case MessageTypeAddPackage:
+		return json.Unmarshal(raw.Value, &m.Value.MsgAddPackage)
+	case MessageTypeRun:
+		return json.Unmarshal(raw.Value, &m.Value.MsgRun)
+	}
+	return nil
+}
+
+// MessageValue 는 Message 의 MarshalJSON 으로만 직렬화된다.
 type MessageValue struct {
-	BankMsgSend   `graphql:"... on BankMsgSend"`
-	MsgCall       `graphql:"... on MsgCall"`
-	MsgAddPackage `graphql:"... on MsgAddPackage"`
-	MsgRun        `graphql:"... on MsgRun"`
+	BankMsgSend   `json:"-" graphql:"... on BankMsgSend"`
+	MsgCall       `json:"-" graphql:"... on MsgCall"`
+	MsgAddPackage `json:"-" graphql:"... on MsgAddPackage"`
+	MsgRun        `json:"-" graphql:"... on MsgRun"`
 }
 
 type BankMsgSend struct {
-	FromAddress string `graphql:"from_address"`
-	ToAddress   string `graphql:"to_address"`
-	Amount      string `graphql:"amount"`
+	FromAddress string `json:"from_address" graphql:"from_address"`
+	ToAddress   string `json:"to_address" graphql:"to_address"`
+	Amount      string `json:"amount" graphql:"amount"`
 }
 
 type MsgCall struct {
-	Caller  string   `graphql:"caller"`
-	Send    string   `graphql:"send"`
-	PkgPath string   `graphql:"pkg_path"`
-	Func    string   `graphql:"func"`
-	Args    []string `graphql:"args"`
+	Caller  string   `json:"caller" graphql:"caller"`
+	Send    string   `json:"send" graphql:"send"`
+	PkgPath string   `json:"pkg_path" graphql:"pkg_path"`
+	Func    string   `json:"func" graphql:"func"`
+	Args    []string `json:"args" graphql:"args"`
 }
 
 type MsgAddPackage struct {
-	Creator string     `graphql:"creator"`
-	Deposit string     `graphql:"deposit"`
-	Package MemPackage `graphql:"package"`
+	Creator string     `json:"creator" graphql:"creator"`
+	Deposit string     `json:"deposit" graphql:"deposit"`
+	Package MemPackage `json:"package" graphql:"package"`
 }
 
 type MsgRun struct {
-	Caller  string     `graphql:"caller"`
-	Send    string     `graphql:"send"`
-	Package MemPackage `graphql:"package"`
+	Caller  string     `json:"caller" graphql:"caller"`
+	Send    string     `json:"send" graphql:"send"`
+	Package MemPackage `json:"package" graphql:"package"`
 }
 
 type MemPackage struct {
-	Name  string `graphql:"name"`
-	Path  string `graphql:"path"`
-	Files []File `graphql:"files"`
+	Name  string `json:"name" graphql:"name"`
+	Path  string `json:"path" graphql:"path"`
+	Files []File `json:"files" graphql:"files"`
 }
 
 type File struct {
-	Name string `graphql:"name"`
-	Body string `graphql:"body"`
+	Name string `json:"name" graphql:"name"`
+	Body string `json:"body" graphql:"body"`
 }
 
 type TransactionResponse struct {
-	Log    string  `graphql:"log"`
-	Info   string  `graphql:"info"`
-	Error  string  `graphql:"error"`
-	Data   string  `graphql:"data"`
-	Events []Event `graphql:"events"`
+	Log    string  `json:"log" graphql:"log"`
+	Info   string  `json:"info" graphql:"info"`
+	Error  string  `json:"error" graphql:"error"`
+	Data   string  `json:"data" graphql:"data"`
+	Events []Event `json:"events" graphql:"events"`
 }
 
 type Event struct {
@@ -191,12 +255,14 @@ func (g GnoEvent) GetAttrs() map[string]string {
 	return attrMap
 }
 
-func (e *Event) ToModel(tei int) *model.TokenEvent {
+func (e *Event) ToModel(chainID, txHash string, tei int) *model.TokenEvent {
 	tokenEvent := &model.TokenEvent{
-		Type:         e.Type,
-		PkgPath:      e.PkgPath,
-		Func:         e.Func,
-		TxEventIndex: tei,
+		ChainID:         chainID,
+		TransactionHash: txHash,
+		Type:            e.Type,
+		PkgPath:         e.PkgPath,
+		Func:            e.Func,
+		TxEventIndex:    tei,
 	}
 	for _, attr := range e.Attrs {
 		switch attr.Key {
@@ -215,6 +281,6 @@ func (e *Event) ToModel(tei int) *model.TokenEvent {
 }
 
 type Attribute struct {
-	Key   string `graphql:"key"`
-	Value string `graphql:"value"`
+	Key   string `json:"key" graphql:"key"`
+	Value string `json:"value" graphql:"value"`
 }
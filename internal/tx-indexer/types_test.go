@@ -0,0 +1,46 @@
+package tx_indexer
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMessage_JSON(t *testing.T) {
+	t.Run("MsgCall 의 caller, send 가 유지됨", func(t *testing.T) {
+		message := Message{
+			Route:   "vm",
+			TypeUrl: MessageTypeCall,
+			Value: MessageValue{MsgCall: MsgCall{
+				Caller:  "g17290cwvmrapvp869xfnhhawa8sm9edpufzat7d",
+				Send:    "1000ugnot",
+				PkgPath: "gno.land/r/gnoswap/v1/router",
+				Func:    "SwapRoute",
+				Args:    []string{"a", "b"},
+			}},
+		}
+
+		data, err := json.Marshal(message)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"route":"vm","typeUrl":"exec","value":{"caller":"g17290cwvmrapvp869xfnhhawa8sm9edpufzat7d","send":"1000ugnot","pkg_path":"gno.land/r/gnoswap/v1/router","func":"SwapRoute","args":["a","b"]}}`, string(data))
+
+		var decoded Message
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, message, decoded)
+	})
+
+	t.Run("MsgRun, MsgAddPackage, BankMsgSend", func(t *testing.T) {
+		messages := []Message{
+			{Route: "vm", TypeUrl: MessageTypeRun, Value: MessageValue{MsgRun: MsgRun{Caller: "g1run", Package: MemPackage{Name: "main", Path: "gno.land/r/g1run/run"}}}},
+			{Route: "vm", TypeUrl: MessageTypeAddPackage, Value: MessageValue{MsgAddPackage: MsgAddPackage{Creator: "g1creator", Package: MemPackage{Name: "foo", Path: "gno.land/r/foo", Files: []File{{Name: "foo.gno", Body: "package foo"}}}}}},
+			{Route: "bank", TypeUrl: MessageTypeSend, Value: MessageValue{BankMsgSend: BankMsgSend{FromAddress: "g1from", ToAddress: "g1to", Amount: "1ugnot"}}},
+		}
+
+		data, err := json.Marshal(messages)
+		assert.Nil(t, err)
+
+		var decoded []Message
+		assert.Nil(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, messages, decoded)
+	})
+}
@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// nonPublicPrefixes 는 netip.Addr 의 메서드로 구분되지 않는 내부용 대역이다. (현재 네트워크, CGNAT)
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// IsPublicAddr 는 loopback, link-local(169.254.169.254 메타데이터 주소 포함), 사설, 미지정, 멀티캐스트 주소가 아닌지 확인한다.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsPrivate() || addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeWebhookURL 은 http(s) 절대 URL 이며 호스트가 localhost 나 공인 주소가 아닌 IP 가 아닌지 확인한다.
+// 도메인이 가리키는 주소는 바뀔 수 있으므로 webhook-dispatcher 가 연결할 때 다시 확인한다.
+func NormalizeWebhookURL(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("is required")
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return "", fmt.Errorf("must be an absolute http or https URL")
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "", fmt.Errorf("must not point to a local or private address")
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+		return "", fmt.Errorf("must not point to a local or private address")
+	}
+	return value, nil
+}
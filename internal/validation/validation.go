@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"fmt"
+	"onbloc/internal/apperror"
+	"regexp"
+	"strings"
+)
+
+const (
+	addressHrp    = "g"
+	addressLength = 20
+	maxPathLength = 255
+)
+
+// realmPathPattern 은 gno 의 realm(r)/package(p) 경로 규칙을 따른다. ex) gno.land/r/gnoswap/v1/test_token/bar
+var realmPathPattern = regexp.MustCompile(`^([a-z0-9-]+\.)+[a-z]{2,}/[rp](/_?[a-z][a-z0-9_]*)+$`)
+
+// NormalizeAddress 는 bech32 체크섬을 포함해 g1 주소를 검증하고 소문자로 정규화한다.
+func NormalizeAddress(address string) (string, error) {
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return "", fmt.Errorf("is required")
+	}
+	hrp, data, err := decodeBech32(address)
+	if err != nil {
+		return "", fmt.Errorf("is not a valid bech32 address: %v", err)
+	}
+	if hrp != addressHrp {
+		return "", fmt.Errorf("must start with %s1", addressHrp)
+	}
+	if len(data) != addressLength {
+		return "", fmt.Errorf("must encode %d bytes", addressLength)
+	}
+	return strings.ToLower(address), nil
+}
+
+// NormalizeRealmPath 는 앞뒤 슬래시를 제거하고 소문자로 바꾼 뒤 realm 경로 형식을 검증한다.
+func NormalizeRealmPath(path string) (string, error) {
+	path = strings.ToLower(strings.Trim(strings.TrimSpace(path), "/"))
+	if path == "" {
+		return "", fmt.Errorf("is required")
+	}
+	if len(path) > maxPathLength {
+		return "", fmt.Errorf("must be at most %d characters", maxPathLength)
+	}
+	if !realmPathPattern.MatchString(path) {
+		return "", fmt.Errorf("is not a valid realm path")
+	}
+	return path, nil
+}
+
+// Validator 는 여러 필드의 검증 오류를 모아 하나의 apperror.ValidationError 로 반환한다.
+type Validator struct {
+	fields []apperror.FieldError
+}
+
+func New() *Validator {
+	return &Validator{}
+}
+
+func (v *Validator) AddError(field, message string) {
+	v.fields = append(v.fields, apperror.FieldError{Field: field, Message: message})
+}
+
+func (v *Validator) Address(field, value string) string {
+	normalized, err := NormalizeAddress(value)
+	if err != nil {
+		v.AddError(field, err.Error())
+	}
+	return normalized
+}
+
+// OptionalAddress 는 값이 비어 있으면 검증하지 않는다.
+func (v *Validator) OptionalAddress(field, value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	return v.Address(field, value)
+}
+
+func (v *Validator) Addresses(field string, values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for i, value := range values {
+		normalized = append(normalized, v.Address(fmt.Sprintf("%s[%d]", field, i), value))
+	}
+	return normalized
+}
+
+func (v *Validator) RealmPath(field, value string) string {
+	normalized, err := NormalizeRealmPath(value)
+	if err != nil {
+		v.AddError(field, err.Error())
+	}
+	return normalized
+}
+
+func (v *Validator) OptionalRealmPath(field, value string) string {
+	if strings.Trim(strings.TrimSpace(value), "/") == "" {
+		return ""
+	}
+	return v.RealmPath(field, value)
+}
+
+func (v *Validator) RealmPaths(field string, values []string) []string {
+	normalized := make([]string, 0, len(values))
+	for i, value := range values {
+		normalized = append(normalized, v.RealmPath(fmt.Sprintf("%s[%d]", field, i), value))
+	}
+	return normalized
+}
+
+func (v *Validator) WebhookURL(field, value string) string {
+	normalized, err := NormalizeWebhookURL(value)
+	if err != nil {
+		v.AddError(field, err.Error())
+	}
+	return normalized
+}
+
+// Err 는 검증 오류가 없으면 nil 을 반환한다.
+func (v *Validator) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &apperror.ValidationError{Fields: v.fields}
+}
@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"onbloc/internal/apperror"
+	"testing"
+)
+
+const testAddress = "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"
+
+func TestNormalizeAddress(t *testing.T) {
+	t.Run("유효한 주소", func(t *testing.T) {
+		address, err := NormalizeAddress(testAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, testAddress, address)
+	})
+
+	t.Run("대문자 주소는 소문자로 정규화한다", func(t *testing.T) {
+		address, err := NormalizeAddress("G1JG8MTUTU9KHHFWC4NXMUHCPFTF0PAJDHFVSQF5")
+		assert.NoError(t, err)
+		assert.Equal(t, testAddress, address)
+	})
+
+	tests := map[string]string{
+		"빈 값":       "",
+		"체크섬 불일치":   "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf6",
+		"대소문자 혼용":   "g1JG8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5",
+		"다른 prefix": "cosmos1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5",
+		"잘못된 문자":    "g1bg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5",
+		"너무 짧은 주소":  "g1abc",
+	}
+	for name, address := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NormalizeAddress(address)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNormalizeRealmPath(t *testing.T) {
+	t.Run("앞뒤 슬래시와 대문자를 정규화한다", func(t *testing.T) {
+		path, err := NormalizeRealmPath("/gno.land/r/gnoswap/v1/Test_Token/bar/")
+		assert.NoError(t, err)
+		assert.Equal(t, "gno.land/r/gnoswap/v1/test_token/bar", path)
+	})
+
+	t.Run("package 경로도 허용한다", func(t *testing.T) {
+		_, err := NormalizeRealmPath("gno.land/p/demo/grc/grc20")
+		assert.NoError(t, err)
+	})
+
+	for _, path := range []string{"", "/", "gno.land", "gno.land/x/demo", "gno.land/r/", "gno.land/r/1demo", "gno.land/r/demo bar", "gno.land/r/demo/../foo"} {
+		t.Run("잘못된 경로 "+path, func(t *testing.T) {
+			_, err := NormalizeRealmPath(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNormalizeWebhookURL(t *testing.T) {
+	for _, value := range []string{"https://example.com/hooks", "http://203.0.113.10:8080/hooks", "https://[2001:db8::1]/hooks"} {
+		t.Run("허용 "+value, func(t *testing.T) {
+			normalized, err := NormalizeWebhookURL(" " + value + " ")
+			assert.NoError(t, err)
+			assert.Equal(t, value, normalized)
+		})
+	}
+
+	for _, value := range []string{
+		"", "example.com/hooks", "ftp://example.com/hooks", "file:///etc/passwd",
+		"http://localhost:8080/hooks", "http://api.localhost/hooks", "http://LOCALHOST./hooks",
+		"http://127.0.0.1/hooks", "http://[::1]/hooks", "http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.5/hooks", "http://192.168.1.1/hooks", "http://172.16.0.1/hooks", "http://[fd00::1]/hooks",
+		"http://0.0.0.0/hooks", "http://100.64.0.1/hooks", "http://[::ffff:127.0.0.1]/hooks",
+	} {
+		t.Run("거부 "+value, func(t *testing.T) {
+			_, err := NormalizeWebhookURL(value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidator(t *testing.T) {
+	t.Run("필드별 오류를 모아 ErrInvalidArgument 로 반환한다", func(t *testing.T) {
+		v := New()
+		v.Addresses("addresses", []string{testAddress, "g1abc"})
+		v.RealmPath("tokenPath", "invalid")
+		v.OptionalAddress("address", "")
+
+		err := v.Err()
+		assert.ErrorIs(t, err, apperror.ErrInvalidArgument)
+
+		var validationErr *apperror.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, []string{"addresses[1]", "tokenPath"}, []string{validationErr.Fields[0].Field, validationErr.Fields[1].Field})
+	})
+
+	t.Run("오류가 없으면 nil 을 반환한다", func(t *testing.T) {
+		v := New()
+		assert.Equal(t, testAddress, v.OptionalAddress("address", testAddress))
+		assert.Empty(t, v.OptionalRealmPath("tokenPath", "/"))
+		assert.NoError(t, v.Err())
+	})
+}
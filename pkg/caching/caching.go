@@ -1,10 +1,18 @@
 package caching
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrCacheMiss 는 키가 없거나 만료되었을 때 Get 이 반환한다.
+var ErrCacheMiss = errors.New("cache miss")
 
 type Caching interface {
 	IncrBy(ctx context.Context, key string, value int64) error
 	DecrBy(ctx context.Context, key string, value int64) error
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value interface{}) error
+	SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 }
@@ -0,0 +1,14 @@
+package caching
+
+import "fmt"
+
+// 잔액 캐시는 버전 키로 무효화한다. event-processor 가 잔액을 갱신하면 주소/토큰의 버전을 올리고,
+// balance-api 는 현재 버전을 포함한 키로 응답을 캐싱하므로 이전 버전의 항목은 더 이상 조회되지 않는다.
+
+func AddressVersionKey(chainID, address string) string {
+	return fmt.Sprintf("balance:version:%s:address:%s", chainID, address)
+}
+
+func TokenVersionKey(chainID, tokenPath string) string {
+	return fmt.Sprintf("balance:version:%s:token:%s", chainID, tokenPath)
+}
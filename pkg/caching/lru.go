@@ -0,0 +1,136 @@
+package caching
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// LRU 는 단일 노드용 인메모리 Caching 구현이다. 용량을 넘으면 가장 오래 사용되지 않은 키부터 제거한다.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+type lruEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time
+}
+
+func NewLRU(capacity int) *LRU {
+	if capacity <= 0 {
+		capacity = 10000
+	}
+	return &LRU{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (c *LRU) IncrBy(ctx context.Context, key string, value int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var current int64
+	if entry, ok := c.lookup(key); ok {
+		parsed, err := strconv.ParseInt(entry.value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("value of %s is not an integer: %w", key, err)
+		}
+		current = parsed
+	}
+	c.store(key, strconv.FormatInt(current+value, 10), 0)
+	return nil
+}
+
+func (c *LRU) DecrBy(ctx context.Context, key string, value int64) error {
+	return c.IncrBy(ctx, key, -value)
+}
+
+func (c *LRU) Get(ctx context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(key)
+	if !ok {
+		return "", ErrCacheMiss
+	}
+	return entry.value, nil
+}
+
+func (c *LRU) Set(ctx context.Context, key string, value interface{}) error {
+	return c.SetWithTTL(ctx, key, value, 0)
+}
+
+func (c *LRU) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.store(key, toString(value), ttl)
+	return nil
+}
+
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// lookup 은 만료된 항목을 지우고, 찾은 항목을 가장 최근 사용으로 옮긴다.
+func (c *LRU) lookup(key string) (*lruEntry, bool) {
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+func (c *LRU) store(key, value string, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// toString 은 redis 클라이언트와 같은 방식으로 값을 문자열로 저장한다.
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,59 @@
+package caching
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("없는 키는 ErrCacheMiss 를 반환한다", func(t *testing.T) {
+		cache := NewLRU(2)
+		_, err := cache.Get(ctx, "missing")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+
+	t.Run("용량을 넘으면 가장 오래 사용되지 않은 키를 제거한다", func(t *testing.T) {
+		cache := NewLRU(2)
+		require.NoError(t, cache.Set(ctx, "a", "1"))
+		require.NoError(t, cache.Set(ctx, "b", "2"))
+		_, err := cache.Get(ctx, "a")
+		require.NoError(t, err)
+		require.NoError(t, cache.Set(ctx, "c", "3"))
+
+		_, err = cache.Get(ctx, "b")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+		value, err := cache.Get(ctx, "a")
+		require.NoError(t, err)
+		assert.Equal(t, "1", value)
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("TTL 이 지난 키는 조회되지 않는다", func(t *testing.T) {
+		now := time.Now()
+		cache := NewLRU(2)
+		cache.now = func() time.Time { return now }
+		require.NoError(t, cache.SetWithTTL(ctx, "a", "1", time.Minute))
+
+		now = now.Add(time.Minute)
+		_, err := cache.Get(ctx, "a")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("IncrBy 와 DecrBy 는 정수 값을 갱신한다", func(t *testing.T) {
+		cache := NewLRU(2)
+		require.NoError(t, cache.IncrBy(ctx, "counter", 5))
+		require.NoError(t, cache.DecrBy(ctx, "counter", 2))
+		value, err := cache.Get(ctx, "counter")
+		require.NoError(t, err)
+		assert.Equal(t, "3", value)
+
+		require.NoError(t, cache.Set(ctx, "text", "abc"))
+		assert.Error(t, cache.IncrBy(ctx, "text", 1))
+	})
+}
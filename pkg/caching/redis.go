@@ -2,7 +2,9 @@ package caching
 
 import (
 	"context"
+	"errors"
 	"github.com/redis/go-redis/v9"
+	"time"
 )
 
 type RedisClient struct {
@@ -11,8 +13,9 @@ type RedisClient struct {
 
 func NewRedisClient(addr, password string, db int) *RedisClient {
 	rdb := redis.NewClient(&redis.Options{
-		Addr: addr,
-		DB:   db,
+		Addr:     addr,
+		Password: password,
+		DB:       db,
 	})
 	return &RedisClient{client: rdb}
 }
@@ -26,9 +29,21 @@ func (c *RedisClient) DecrBy(ctx context.Context, key string, value int64) error
 }
 
 func (c *RedisClient) Get(ctx context.Context, key string) (string, error) {
-	return c.client.Get(ctx, key).Result()
+	value, err := c.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrCacheMiss
+	}
+	return value, err
 }
 
 func (c *RedisClient) Set(ctx context.Context, key string, value interface{}) error {
 	return c.client.Set(ctx, key, value, 0).Err()
 }
+
+func (c *RedisClient) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisClient) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
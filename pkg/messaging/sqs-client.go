@@ -4,18 +4,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
-	"log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
 	"strconv"
 	"time"
 )
 
 type MessageObject struct {
 	ReceiptHandle *string
+	MessageID     string    `json:"-"`
 	CreatedTime   time.Time `json:"createdTime"`
 	JsonData      string    `json:"jsonData"`
+	// TraceContext 는 발행한 쪽의 트레이스 컨텍스트(W3C traceparent 등)이다.
+	TraceContext map[string]string `json:"traceContext,omitempty"`
+}
+
+// Context 는 메시지에 담긴 트레이스 컨텍스트를 ctx 에 이어 붙인다. 수신 측 span 은 이 ctx 로 시작해 발행 측 트레이스에 연결된다.
+func (o MessageObject) Context(ctx context.Context) context.Context {
+	if len(o.TraceContext) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(o.TraceContext))
 }
 
 func (o MessageObject) IsEmpty() bool {
@@ -38,16 +54,26 @@ func NewSQSClient(ctx context.Context, url string) (*SQSClient, error) {
 	cfg.BaseEndpoint = &url
 	sqsClient := sqs.NewFromConfig(cfg)
 	return &SQSClient{
+		url:    url,
 		client: sqsClient,
 	}, nil
 }
 
-func (p SQSClient) PublishMessage(ctx context.Context, event interface{}) error {
+func (p SQSClient) PublishMessage(ctx context.Context, event interface{}) (err error) {
+	ctx, span := otel.Tracer("onbloc/messaging").Start(ctx, "sqs.publish", trace.WithSpanKind(trace.SpanKindProducer))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
 	return p.put(ctx, event)
 }
 
 func (p SQSClient) put(ctx context.Context, obj interface{}) error {
-	mo := MessageObject{}
+	mo := MessageObject{TraceContext: map[string]string{}}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(mo.TraceContext))
 	data, err := json.Marshal(obj)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
@@ -70,12 +96,21 @@ func (p SQSClient) put(ctx context.Context, obj interface{}) error {
 
 	_, err = p.client.SendMessage(ctx, &message)
 	if err != nil {
-		log.Printf("Failed to send SQS message: %v", err)
+		slog.ErrorContext(ctx, "failed to send sqs message", "error", err)
 		return fmt.Errorf("failed to send SQS message: %w", err)
 	}
 	return nil
 }
 
+// Ping 은 큐 속성을 조회해 큐에 접근 가능한지 확인한다.
+func (p SQSClient) Ping(ctx context.Context) error {
+	_, err := p.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       &p.url,
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameQueueArn},
+	})
+	return err
+}
+
 func (p SQSClient) GetMessageCount(ctx context.Context) int {
 	result, err := p.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
 		QueueUrl: &p.url,
@@ -114,6 +149,39 @@ func (p SQSClient) ReceiveMessage(ctx context.Context) (message MessageObject, e
 	return
 }
 
+// ReceiveMessages 는 최대 count(1~10)개의 메시지를 받는다. 받은 메시지는 DeleteMessage 전까지 가시성 제한 시간 동안 숨겨진다.
+func (p SQSClient) ReceiveMessages(ctx context.Context, count int32) ([]MessageObject, error) {
+	return p.get(ctx, count)
+}
+
+// ReleaseMessage 는 받은 메시지의 가시성 제한 시간을 0 으로 되돌려 다른 소비자가 곧바로 다시 받을 수 있게 한다.
+func (p SQSClient) ReleaseMessage(ctx context.Context, message MessageObject) error {
+	_, err := p.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          &p.url,
+		ReceiptHandle:     message.ReceiptHandle,
+		VisibilityTimeout: 0,
+	})
+	return err
+}
+
+// Republish 는 다른 큐에서 받은 메시지를 생성 시각과 트레이스 컨텍스트를 유지한 채 이 큐로 보낸다.
+func (p SQSClient) Republish(ctx context.Context, message MessageObject) error {
+	message.ReceiptHandle = nil
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message object: %w", err)
+	}
+	messageBody := string(data)
+	_, err = p.client.SendMessage(ctx, &sqs.SendMessageInput{
+		MessageBody: &messageBody,
+		QueueUrl:    &p.url,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send SQS message: %w", err)
+	}
+	return nil
+}
+
 func (p SQSClient) get(ctx context.Context, count int32) (attrs []MessageObject, err error) {
 	params := &sqs.ReceiveMessageInput{
 		QueueUrl:            &p.url,
@@ -130,9 +198,10 @@ func (p SQSClient) get(ctx context.Context, count int32) (attrs []MessageObject,
 		var msgObj MessageObject
 		err = json.Unmarshal([]byte(*msg.Body), &msgObj)
 		if err != nil {
-			log.Printf("fail to unmarshal message: %v", err)
+			slog.WarnContext(ctx, "failed to unmarshal message", "message_id", aws.ToString(msg.MessageId), "error", err)
 		}
 		msgObj.ReceiptHandle = msg.ReceiptHandle
+		msgObj.MessageID = aws.ToString(msg.MessageId)
 		messages = append(messages, msgObj)
 	}
 	return messages, nil
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ActivityTypeTransferIn  = "transfer_in"
+	ActivityTypeTransferOut = "transfer_out"
+	ActivityTypeMint        = "mint"
+	ActivityTypeBurn        = "burn"
+	ActivityTypeCall        = "call"
+	ActivityTypeRun         = "run"
+	ActivityTypeDeploy      = "deploy"
+	ActivityTypeSend        = "send"
+	ActivityTypeReceive     = "receive"
+)
+
+var ActivityTypes = []string{
+	ActivityTypeTransferIn,
+	ActivityTypeTransferOut,
+	ActivityTypeMint,
+	ActivityTypeBurn,
+	ActivityTypeCall,
+	ActivityTypeRun,
+	ActivityTypeDeploy,
+	ActivityTypeSend,
+	ActivityTypeReceive,
+}
+
+// AccountActivity 는 token_events 와 transactions.messages 를 합친 계정 활동 한 건이다.
+// (BlockHeight, TxIndex, SourceOrder, Seq) 가 정렬 및 커서 키이다.
+type AccountActivity struct {
+	Type            string          `gorm:"column:type"`
+	BlockHeight     int64           `gorm:"column:block_height"`
+	TxIndex         int64           `gorm:"column:tx_index"`
+	SourceOrder     int             `gorm:"column:source_order"`
+	Seq             int             `gorm:"column:seq"`
+	Time            time.Time       `gorm:"column:time"`
+	TransactionHash string          `gorm:"column:transaction_hash"`
+	Success         bool            `gorm:"column:success"`
+	GasUsed         int64           `gorm:"column:gas_used"`
+	GasFee          json.RawMessage `gorm:"column:gas_fee"`
+	PkgPath         string          `gorm:"column:pkg_path"`
+	Func            string          `gorm:"column:func"`
+	From            string          `gorm:"column:from_addr"`
+	To              string          `gorm:"column:to_addr"`
+	Amount          int64           `gorm:"column:amount"`
+	Coins           string          `gorm:"column:coins"`
+}
+
+type ActivityCursor struct {
+	BlockHeight int64
+	TxIndex     int64
+	SourceOrder int
+	Seq         int
+}
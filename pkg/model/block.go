@@ -9,8 +9,9 @@ import (
 
 type Block struct {
 	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Hash      string    `json:"hash" gorm:"unique;not null;size:64"`
-	Height    int64     `json:"height" gorm:"unique;not null;index"`
+	ChainID   string    `json:"chain_id" gorm:"column:chain_id;not null;uniqueIndex:uk_blocks_chain_hash;uniqueIndex:uk_blocks_chain_height"`
+	Hash      string    `json:"hash" gorm:"not null;size:255;uniqueIndex:uk_blocks_chain_hash"`
+	Height    int64     `json:"height" gorm:"not null;uniqueIndex:uk_blocks_chain_height"`
 	Time      time.Time `json:"time" gorm:"not null;index"`
 	NumTxs    int       `json:"num_txs" gorm:"not null;default:0"`
 	TotalTxs  int64     `json:"total_txs" gorm:"not null;default:0"`
@@ -20,8 +21,9 @@ type Block struct {
 
 type BlockTransaction struct {
 	ID          int64           `gorm:"primaryKey"`
+	ChainID     string          `gorm:"column:chain_id;uniqueIndex:uk_transactions_chain_hash"`
 	IndexNum    int64           `gorm:"column:index_num"`
-	Hash        string          `gorm:"column:hash;unique"`
+	Hash        string          `gorm:"column:hash;uniqueIndex:uk_transactions_chain_hash"`
 	BlockHeight int64           `gorm:"column:block_height"`
 	Success     bool            `gorm:"column:success"`
 	GasWanted   int64           `gorm:"column:gas_wanted"`
@@ -38,13 +40,15 @@ func (BlockTransaction) TableName() string {
 }
 
 type TokenEvent struct {
+	ID              int64  `json:"id,omitempty" gorm:"column:id;primaryKey"`
+	ChainID         string `json:"chainId" gorm:"column:chain_id;not null;index:idx_token_events_chain_from,priority:1;index:idx_token_events_chain_to,priority:1"`
 	TransactionHash string `json:"transactionHash" gorm:"column:transaction_hash;not null"`
 	TxEventIndex    int    `json:"TxEventIndex" gorm:"column:tx_event_index; not null"`
 	Type            string `json:"type" gorm:"column:type;not null"`
 	PkgPath         string `json:"pkg_path" gorm:"column:pkg_path;not null"`
 	Func            string `json:"func" gorm:"column:func;not null"`
-	From            string `json:"from" gorm:"column:from_addr;not null"`
-	To              string `json:"to" gorm:"column:to_addr; not null"`
+	From            string `json:"from" gorm:"column:from_addr;not null;index:idx_token_events_chain_from,priority:2"`
+	To              string `json:"to" gorm:"column:to_addr; not null;index:idx_token_events_chain_to,priority:2"`
 	Amount          int64  `json:"amount" gorm:"column:amount; not null"`
 }
 
@@ -64,13 +68,49 @@ func (t TokenEventAttributes) Value() (driver.Value, error) {
 
 type Balance struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
-	Address   string    `gorm:"type:varchar(255);not null;uniqueIndex:uk_balances_address_token" json:"address"`
-	TokenPath string    `gorm:"type:varchar(255);not null;uniqueIndex:uk_balances_address_token;column:token_path" json:"token_path"`
+	ChainID   string    `gorm:"type:varchar(64);not null;uniqueIndex:uk_balances_chain_address_token;index:idx_balances_chain_token,priority:1;column:chain_id" json:"chain_id"`
+	Address   string    `gorm:"type:varchar(255);not null;uniqueIndex:uk_balances_chain_address_token" json:"address"`
+	TokenPath string    `gorm:"type:varchar(255);not null;uniqueIndex:uk_balances_chain_address_token;index:idx_balances_chain_token,priority:2;column:token_path" json:"token_path"`
 	Amount    int64     `gorm:"type:bigint;not null;default:0" json:"amount"`
 	CreatedAt time.Time `gorm:"type:timestamp;default:now()" json:"created_at"`
 	UpdatedAt time.Time `gorm:"type:timestamp;default:now()" json:"updated_at"`
+	// TraceParent 는 잔액을 마지막으로 갱신한 이벤트 처리의 W3C traceparent 이다.
+	TraceParent string `gorm:"type:varchar(55);column:trace_parent" json:"-"`
 }
 
 func (b Balance) TableName() string {
 	return "balances"
 }
+
+// Token 은 balances 의 token_path 별 집계 결과이다.
+type Token struct {
+	TokenPath string `gorm:"column:token_path"`
+	Holders   int64  `gorm:"column:holders"`
+}
+
+type TokenEventFilter struct {
+	Address   string
+	TokenPath string
+	Func      string
+}
+
+// SyncCursor 는 block-synchronizer 가 마지막으로 동기화한 높이이다. 없으면 blocks 의 최대 높이를 사용한다.
+// FencingToken 은 커서를 마지막으로 옮긴 리더의 토큰이며, 더 작은 토큰으로는 커서를 옮길 수 없다.
+type SyncCursor struct {
+	ChainID      string    `gorm:"column:chain_id;primaryKey"`
+	Height       int64     `gorm:"column:height"`
+	FencingToken int64     `gorm:"column:fencing_token"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (SyncCursor) TableName() string {
+	return "sync_cursors"
+}
+
+// BalanceMismatch 는 저장된 잔액(Stored)과 token_events 로 다시 계산한 잔액(Expected)이 다른 항목이다.
+type BalanceMismatch struct {
+	TokenPath string `gorm:"column:token_path" json:"tokenPath"`
+	Address   string `gorm:"column:address" json:"address"`
+	Stored    int64  `gorm:"column:stored" json:"stored"`
+	Expected  int64  `gorm:"column:expected" json:"expected"`
+}
@@ -0,0 +1,10 @@
+package model
+
+// TokenEventChannel 은 event-processor 가 커밋한 토큰 이벤트를 알리는 Postgres NOTIFY 채널이다.
+const TokenEventChannel = "token_event_processed"
+
+// TokenEventNotification 은 처리된 이벤트와 그로 인해 변경된 잔액이다.
+type TokenEventNotification struct {
+	Event    TokenEvent `json:"event"`
+	Balances []Balance  `json:"balances"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+const (
+	VolumeIntervalHour = "1h"
+	VolumeIntervalDay  = "1d"
+)
+
+var VolumeIntervals = map[string]time.Duration{
+	VolumeIntervalHour: time.Hour,
+	VolumeIntervalDay:  24 * time.Hour,
+}
+
+const (
+	VolumeRoleSender   = "sender"
+	VolumeRoleReceiver = "receiver"
+)
+
+// TokenVolume 은 (chain_id, token_path, bucket_interval, bucket_start) 단위로 집계된 토큰 이벤트 롤업이다.
+type TokenVolume struct {
+	ChainID         string    `gorm:"column:chain_id;primaryKey"`
+	TokenPath       string    `gorm:"column:token_path;primaryKey"`
+	Interval        string    `gorm:"column:bucket_interval;primaryKey"`
+	BucketStart     time.Time `gorm:"column:bucket_start;primaryKey"`
+	TransferCount   int64     `gorm:"column:transfer_count;not null;default:0"`
+	TransferVolume  int64     `gorm:"column:transfer_volume;not null;default:0"`
+	MintCount       int64     `gorm:"column:mint_count;not null;default:0"`
+	MintVolume      int64     `gorm:"column:mint_volume;not null;default:0"`
+	BurnCount       int64     `gorm:"column:burn_count;not null;default:0"`
+	BurnVolume      int64     `gorm:"column:burn_volume;not null;default:0"`
+	UniqueSenders   int64     `gorm:"column:unique_senders;not null;default:0"`
+	UniqueReceivers int64     `gorm:"column:unique_receivers;not null;default:0"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (TokenVolume) TableName() string {
+	return "token_volumes"
+}
+
+// TokenVolumeParticipant 는 버킷별 고유 송신자/수신자 집계를 위해 주소를 기록한다.
+type TokenVolumeParticipant struct {
+	ChainID     string    `gorm:"column:chain_id;primaryKey"`
+	TokenPath   string    `gorm:"column:token_path;primaryKey"`
+	Interval    string    `gorm:"column:bucket_interval;primaryKey"`
+	BucketStart time.Time `gorm:"column:bucket_start;primaryKey"`
+	Role        string    `gorm:"column:role;primaryKey"`
+	Address     string    `gorm:"column:address;primaryKey"`
+}
+
+func (TokenVolumeParticipant) TableName() string {
+	return "token_volume_participants"
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription 의 필터는 빈 값(0)이면 조건을 적용하지 않는다.
+type WebhookSubscription struct {
+	ID        int64     `gorm:"column:id;primaryKey" json:"id"`
+	ChainID   string    `gorm:"column:chain_id;not null" json:"chain_id"`
+	URL       string    `gorm:"column:url;not null" json:"url"`
+	Secret    string    `gorm:"column:secret;not null" json:"-"`
+	Address   string    `gorm:"column:address;not null;default:''" json:"address"`
+	TokenPath string    `gorm:"column:token_path;not null;default:''" json:"token_path"`
+	EventFunc string    `gorm:"column:event_func;not null;default:''" json:"event_func"`
+	MinAmount int64     `gorm:"column:min_amount;not null;default:0" json:"min_amount"`
+	Active    bool      `gorm:"column:active;not null;default:true" json:"active"`
+	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime" json:"updated_at"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type WebhookDelivery struct {
+	ID              int64           `gorm:"column:id;primaryKey"`
+	SubscriptionID  int64           `gorm:"column:subscription_id;not null"`
+	ChainID         string          `gorm:"column:chain_id;not null"`
+	TransactionHash string          `gorm:"column:transaction_hash;not null"`
+	TxEventIndex    int             `gorm:"column:tx_event_index;not null"`
+	Payload         json.RawMessage `gorm:"column:payload;type:jsonb;not null"`
+	Status          string          `gorm:"column:status;not null"`
+	Attempts        int             `gorm:"column:attempts;not null;default:0"`
+	LastStatusCode  int             `gorm:"column:last_status_code"`
+	LastError       string          `gorm:"column:last_error"`
+	NextAttemptAt   time.Time       `gorm:"column:next_attempt_at;not null"`
+	DeliveredAt     *time.Time      `gorm:"column:delivered_at"`
+	CreatedAt       time.Time       `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+type WebhookDeliveryAttempt struct {
+	ID         int64     `gorm:"column:id;primaryKey"`
+	DeliveryID int64     `gorm:"column:delivery_id;not null"`
+	Attempt    int       `gorm:"column:attempt;not null"`
+	StatusCode int       `gorm:"column:status_code"`
+	Error      string    `gorm:"column:error"`
+	DurationMs int64     `gorm:"column:duration_ms"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
+
+// WebhookPayload 는 구독자에게 전송되는 본문이다. Event 는 WebhookDelivery.Payload(TokenEvent JSON)이다.
+type WebhookPayload struct {
+	DeliveryID     int64           `json:"deliveryId"`
+	SubscriptionID int64           `json:"subscriptionId"`
+	Event          json.RawMessage `json:"event"`
+}
@@ -0,0 +1,838 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.3
+// source: balance/v1/balance.proto
+
+package balancev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TokenBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenPath     string                 `protobuf:"bytes,1,opt,name=token_path,json=tokenPath,proto3" json:"token_path,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenBalance) Reset() {
+	*x = TokenBalance{}
+	mi := &file_balance_v1_balance_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenBalance) ProtoMessage() {}
+
+func (x *TokenBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenBalance.ProtoReflect.Descriptor instead.
+func (*TokenBalance) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TokenBalance) GetTokenPath() string {
+	if x != nil {
+		return x.TokenPath
+	}
+	return ""
+}
+
+func (x *TokenBalance) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type AccountBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	TokenPath     string                 `protobuf:"bytes,2,opt,name=token_path,json=tokenPath,proto3" json:"token_path,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountBalance) Reset() {
+	*x = AccountBalance{}
+	mi := &file_balance_v1_balance_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountBalance) ProtoMessage() {}
+
+func (x *AccountBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
+func (*AccountBalance) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AccountBalance) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AccountBalance) GetTokenPath() string {
+	if x != nil {
+		return x.TokenPath
+	}
+	return ""
+}
+
+func (x *AccountBalance) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type Transfer struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TransactionHash string                 `protobuf:"bytes,1,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TxEventIndex    int32                  `protobuf:"varint,2,opt,name=tx_event_index,json=txEventIndex,proto3" json:"tx_event_index,omitempty"`
+	Func            string                 `protobuf:"bytes,3,opt,name=func,proto3" json:"func,omitempty"`
+	FromAddress     string                 `protobuf:"bytes,4,opt,name=from_address,json=fromAddress,proto3" json:"from_address,omitempty"`
+	ToAddress       string                 `protobuf:"bytes,5,opt,name=to_address,json=toAddress,proto3" json:"to_address,omitempty"`
+	TokenPath       string                 `protobuf:"bytes,6,opt,name=token_path,json=tokenPath,proto3" json:"token_path,omitempty"`
+	Amount          int64                  `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_balance_v1_balance_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transfer) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *Transfer) GetTxEventIndex() int32 {
+	if x != nil {
+		return x.TxEventIndex
+	}
+	return 0
+}
+
+func (x *Transfer) GetFunc() string {
+	if x != nil {
+		return x.Func
+	}
+	return ""
+}
+
+func (x *Transfer) GetFromAddress() string {
+	if x != nil {
+		return x.FromAddress
+	}
+	return ""
+}
+
+func (x *Transfer) GetToAddress() string {
+	if x != nil {
+		return x.ToAddress
+	}
+	return ""
+}
+
+func (x *Transfer) GetTokenPath() string {
+	if x != nil {
+		return x.TokenPath
+	}
+	return ""
+}
+
+func (x *Transfer) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type GetBalancesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancesRequest) Reset() {
+	*x = GetBalancesRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancesRequest) ProtoMessage() {}
+
+func (x *GetBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancesRequest.ProtoReflect.Descriptor instead.
+func (*GetBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBalancesRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *GetBalancesRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GetBalancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balances      []*TokenBalance        `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancesResponse) Reset() {
+	*x = GetBalancesResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancesResponse) ProtoMessage() {}
+
+func (x *GetBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancesResponse.ProtoReflect.Descriptor instead.
+func (*GetBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetBalancesResponse) GetBalances() []*TokenBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+type GetTokenBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TokenPath     string                 `protobuf:"bytes,2,opt,name=token_path,json=tokenPath,proto3" json:"token_path,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenBalanceRequest) Reset() {
+	*x = GetTokenBalanceRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenBalanceRequest) ProtoMessage() {}
+
+func (x *GetTokenBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetTokenBalanceRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *GetTokenBalanceRequest) GetTokenPath() string {
+	if x != nil {
+		return x.TokenPath
+	}
+	return ""
+}
+
+func (x *GetTokenBalanceRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ListHoldersRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChainId   string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TokenPath string                 `protobuf:"bytes,2,opt,name=token_path,json=tokenPath,proto3" json:"token_path,omitempty"`
+	// first 는 최대 100 이며 0 이면 100 을 사용한다.
+	First         int32  `protobuf:"varint,3,opt,name=first,proto3" json:"first,omitempty"`
+	After         string `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldersRequest) Reset() {
+	*x = ListHoldersRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldersRequest) ProtoMessage() {}
+
+func (x *ListHoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldersRequest.ProtoReflect.Descriptor instead.
+func (*ListHoldersRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListHoldersRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ListHoldersRequest) GetTokenPath() string {
+	if x != nil {
+		return x.TokenPath
+	}
+	return ""
+}
+
+func (x *ListHoldersRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *ListHoldersRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+type ListHoldersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holders       []*AccountBalance      `protobuf:"bytes,1,rep,name=holders,proto3" json:"holders,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldersResponse) Reset() {
+	*x = ListHoldersResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldersResponse) ProtoMessage() {}
+
+func (x *ListHoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldersResponse.ProtoReflect.Descriptor instead.
+func (*ListHoldersResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListHoldersResponse) GetHolders() []*AccountBalance {
+	if x != nil {
+		return x.Holders
+	}
+	return nil
+}
+
+func (x *ListHoldersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ListTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	TokenPath     string                 `protobuf:"bytes,3,opt,name=token_path,json=tokenPath,proto3" json:"token_path,omitempty"`
+	Func          string                 `protobuf:"bytes,4,opt,name=func,proto3" json:"func,omitempty"`
+	First         int32                  `protobuf:"varint,5,opt,name=first,proto3" json:"first,omitempty"`
+	After         string                 `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersRequest) Reset() {
+	*x = ListTransfersRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersRequest) ProtoMessage() {}
+
+func (x *ListTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListTransfersRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetTokenPath() string {
+	if x != nil {
+		return x.TokenPath
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetFunc() string {
+	if x != nil {
+		return x.Func
+	}
+	return ""
+}
+
+func (x *ListTransfersRequest) GetFirst() int32 {
+	if x != nil {
+		return x.First
+	}
+	return 0
+}
+
+func (x *ListTransfersRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+type ListTransfersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*Transfer            `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransfersResponse) Reset() {
+	*x = ListTransfersResponse{}
+	mi := &file_balance_v1_balance_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransfersResponse) ProtoMessage() {}
+
+func (x *ListTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListTransfersResponse) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *ListTransfersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type StreamTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Addresses     []string               `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	TokenPaths    []string               `protobuf:"bytes,3,rep,name=token_paths,json=tokenPaths,proto3" json:"token_paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTransfersRequest) Reset() {
+	*x = StreamTransfersRequest{}
+	mi := &file_balance_v1_balance_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTransfersRequest) ProtoMessage() {}
+
+func (x *StreamTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_v1_balance_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTransfersRequest.ProtoReflect.Descriptor instead.
+func (*StreamTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_balance_v1_balance_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StreamTransfersRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *StreamTransfersRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *StreamTransfersRequest) GetTokenPaths() []string {
+	if x != nil {
+		return x.TokenPaths
+	}
+	return nil
+}
+
+var File_balance_v1_balance_proto protoreflect.FileDescriptor
+
+const file_balance_v1_balance_proto_rawDesc = "" +
+	"\n" +
+	"\x18balance/v1/balance.proto\x12\x11onbloc.balance.v1\"E\n" +
+	"\fTokenBalance\x12\x1d\n" +
+	"\n" +
+	"token_path\x18\x01 \x01(\tR\ttokenPath\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"a\n" +
+	"\x0eAccountBalance\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"token_path\x18\x02 \x01(\tR\ttokenPath\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"\xe8\x01\n" +
+	"\bTransfer\x12)\n" +
+	"\x10transaction_hash\x18\x01 \x01(\tR\x0ftransactionHash\x12$\n" +
+	"\x0etx_event_index\x18\x02 \x01(\x05R\ftxEventIndex\x12\x12\n" +
+	"\x04func\x18\x03 \x01(\tR\x04func\x12!\n" +
+	"\ffrom_address\x18\x04 \x01(\tR\vfromAddress\x12\x1d\n" +
+	"\n" +
+	"to_address\x18\x05 \x01(\tR\ttoAddress\x12\x1d\n" +
+	"\n" +
+	"token_path\x18\x06 \x01(\tR\ttokenPath\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x03R\x06amount\"I\n" +
+	"\x12GetBalancesRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"R\n" +
+	"\x13GetBalancesResponse\x12;\n" +
+	"\bbalances\x18\x01 \x03(\v2\x1f.onbloc.balance.v1.TokenBalanceR\bbalances\"l\n" +
+	"\x16GetTokenBalanceRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1d\n" +
+	"\n" +
+	"token_path\x18\x02 \x01(\tR\ttokenPath\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"z\n" +
+	"\x12ListHoldersRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1d\n" +
+	"\n" +
+	"token_path\x18\x02 \x01(\tR\ttokenPath\x12\x14\n" +
+	"\x05first\x18\x03 \x01(\x05R\x05first\x12\x14\n" +
+	"\x05after\x18\x04 \x01(\tR\x05after\"s\n" +
+	"\x13ListHoldersResponse\x12;\n" +
+	"\aholders\x18\x01 \x03(\v2!.onbloc.balance.v1.AccountBalanceR\aholders\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xaa\x01\n" +
+	"\x14ListTransfersRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"token_path\x18\x03 \x01(\tR\ttokenPath\x12\x12\n" +
+	"\x04func\x18\x04 \x01(\tR\x04func\x12\x14\n" +
+	"\x05first\x18\x05 \x01(\x05R\x05first\x12\x14\n" +
+	"\x05after\x18\x06 \x01(\tR\x05after\"s\n" +
+	"\x15ListTransfersResponse\x129\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x1b.onbloc.balance.v1.TransferR\ttransfers\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"r\n" +
+	"\x16StreamTransfersRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1c\n" +
+	"\taddresses\x18\x02 \x03(\tR\taddresses\x12\x1f\n" +
+	"\vtoken_paths\x18\x03 \x03(\tR\n" +
+	"tokenPaths2\xee\x03\n" +
+	"\x0eBalanceService\x12\\\n" +
+	"\vGetBalances\x12%.onbloc.balance.v1.GetBalancesRequest\x1a&.onbloc.balance.v1.GetBalancesResponse\x12_\n" +
+	"\x0fGetTokenBalance\x12).onbloc.balance.v1.GetTokenBalanceRequest\x1a!.onbloc.balance.v1.AccountBalance\x12\\\n" +
+	"\vListHolders\x12%.onbloc.balance.v1.ListHoldersRequest\x1a&.onbloc.balance.v1.ListHoldersResponse\x12b\n" +
+	"\rListTransfers\x12'.onbloc.balance.v1.ListTransfersRequest\x1a(.onbloc.balance.v1.ListTransfersResponse\x12[\n" +
+	"\x0fStreamTransfers\x12).onbloc.balance.v1.StreamTransfersRequest\x1a\x1b.onbloc.balance.v1.Transfer0\x01B$Z\"onbloc/pkg/pb/balance/v1;balancev1b\x06proto3"
+
+var (
+	file_balance_v1_balance_proto_rawDescOnce sync.Once
+	file_balance_v1_balance_proto_rawDescData []byte
+)
+
+func file_balance_v1_balance_proto_rawDescGZIP() []byte {
+	file_balance_v1_balance_proto_rawDescOnce.Do(func() {
+		file_balance_v1_balance_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_balance_v1_balance_proto_rawDesc), len(file_balance_v1_balance_proto_rawDesc)))
+	})
+	return file_balance_v1_balance_proto_rawDescData
+}
+
+var file_balance_v1_balance_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_balance_v1_balance_proto_goTypes = []any{
+	(*TokenBalance)(nil),           // 0: onbloc.balance.v1.TokenBalance
+	(*AccountBalance)(nil),         // 1: onbloc.balance.v1.AccountBalance
+	(*Transfer)(nil),               // 2: onbloc.balance.v1.Transfer
+	(*GetBalancesRequest)(nil),     // 3: onbloc.balance.v1.GetBalancesRequest
+	(*GetBalancesResponse)(nil),    // 4: onbloc.balance.v1.GetBalancesResponse
+	(*GetTokenBalanceRequest)(nil), // 5: onbloc.balance.v1.GetTokenBalanceRequest
+	(*ListHoldersRequest)(nil),     // 6: onbloc.balance.v1.ListHoldersRequest
+	(*ListHoldersResponse)(nil),    // 7: onbloc.balance.v1.ListHoldersResponse
+	(*ListTransfersRequest)(nil),   // 8: onbloc.balance.v1.ListTransfersRequest
+	(*ListTransfersResponse)(nil),  // 9: onbloc.balance.v1.ListTransfersResponse
+	(*StreamTransfersRequest)(nil), // 10: onbloc.balance.v1.StreamTransfersRequest
+}
+var file_balance_v1_balance_proto_depIdxs = []int32{
+	0,  // 0: onbloc.balance.v1.GetBalancesResponse.balances:type_name -> onbloc.balance.v1.TokenBalance
+	1,  // 1: onbloc.balance.v1.ListHoldersResponse.holders:type_name -> onbloc.balance.v1.AccountBalance
+	2,  // 2: onbloc.balance.v1.ListTransfersResponse.transfers:type_name -> onbloc.balance.v1.Transfer
+	3,  // 3: onbloc.balance.v1.BalanceService.GetBalances:input_type -> onbloc.balance.v1.GetBalancesRequest
+	5,  // 4: onbloc.balance.v1.BalanceService.GetTokenBalance:input_type -> onbloc.balance.v1.GetTokenBalanceRequest
+	6,  // 5: onbloc.balance.v1.BalanceService.ListHolders:input_type -> onbloc.balance.v1.ListHoldersRequest
+	8,  // 6: onbloc.balance.v1.BalanceService.ListTransfers:input_type -> onbloc.balance.v1.ListTransfersRequest
+	10, // 7: onbloc.balance.v1.BalanceService.StreamTransfers:input_type -> onbloc.balance.v1.StreamTransfersRequest
+	4,  // 8: onbloc.balance.v1.BalanceService.GetBalances:output_type -> onbloc.balance.v1.GetBalancesResponse
+	1,  // 9: onbloc.balance.v1.BalanceService.GetTokenBalance:output_type -> onbloc.balance.v1.AccountBalance
+	7,  // 10: onbloc.balance.v1.BalanceService.ListHolders:output_type -> onbloc.balance.v1.ListHoldersResponse
+	9,  // 11: onbloc.balance.v1.BalanceService.ListTransfers:output_type -> onbloc.balance.v1.ListTransfersResponse
+	2,  // 12: onbloc.balance.v1.BalanceService.StreamTransfers:output_type -> onbloc.balance.v1.Transfer
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_balance_v1_balance_proto_init() }
+func file_balance_v1_balance_proto_init() {
+	if File_balance_v1_balance_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_balance_v1_balance_proto_rawDesc), len(file_balance_v1_balance_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_balance_v1_balance_proto_goTypes,
+		DependencyIndexes: file_balance_v1_balance_proto_depIdxs,
+		MessageInfos:      file_balance_v1_balance_proto_msgTypes,
+	}.Build()
+	File_balance_v1_balance_proto = out.File
+	file_balance_v1_balance_proto_goTypes = nil
+	file_balance_v1_balance_proto_depIdxs = nil
+}